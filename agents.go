@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// subAgentNames are the names of the coordinator's sub-agents.
+var subAgentNames = []string{"Booker", "Info"}
+
+// orderNames arranges names in the given comma-separated order. Every name
+// must appear exactly once.
+func orderNames(order string, names []string) ([]string, error) {
+	remaining := make(map[string]bool, len(names))
+	for _, name := range names {
+		remaining[name] = true
+	}
+
+	var ordered []string
+	for _, name := range strings.Split(order, ",") {
+		name = strings.TrimSpace(name)
+		if !remaining[name] {
+			return nil, fmt.Errorf("unknown or repeated sub-agent %q", name)
+		}
+		ordered = append(ordered, name)
+		delete(remaining, name)
+	}
+	if len(remaining) > 0 {
+		var missing []string
+		for _, name := range names {
+			if remaining[name] {
+				missing = append(missing, name)
+			}
+		}
+		return nil, fmt.Errorf("sub-agent order is missing %s", strings.Join(missing, ", "))
+	}
+	return ordered, nil
+}
+
+// orderSubAgents arranges agents in the comma-separated order of names.
+// Every agent must be named exactly once.
+func orderSubAgents(agents []agent.Agent, order string) ([]agent.Agent, error) {
+	names, err := orderNames(order, agentNames(agents))
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]agent.Agent, len(agents))
+	for _, a := range agents {
+		byName[a.Name()] = a
+	}
+	ordered := make([]agent.Agent, len(names))
+	for i, name := range names {
+		ordered[i] = byName[name]
+	}
+	return ordered, nil
+}
+
+// agentNames returns the names of agents in order.
+func agentNames(agents []agent.Agent) []string {
+	names := make([]string, len(agents))
+	for i, a := range agents {
+		names[i] = a.Name()
+	}
+	return names
+}
+
+// agentModels are the models the coordinator and its sub-agents run on.
+type agentModels struct {
+	Coordinator, Booker, Info model.LLM
+}
+
+// configuredAgentModels returns the model of each agent as set by -model,
+// -booker-model and -info-model.
+func configuredAgentModels(models *modelCache) (agentModels, error) {
+	var m agentModels
+	var err error
+	if m.Coordinator, err = models.get(*modelName); err != nil {
+		return agentModels{}, err
+	}
+	if m.Booker, err = models.get(agentModelName(*bookerModelName)); err != nil {
+		return agentModels{}, err
+	}
+	if m.Info, err = models.get(agentModelName(*infoModelName)); err != nil {
+		return agentModels{}, err
+	}
+	return m, nil
+}
+
+// agentSetup is what the agents are built from besides the flags.
+type agentSetup struct {
+	Models                                   agentModels
+	BookerTools, InfoTools, CoordinatorTools []tool.Tool
+	BeforeToolCallbacks                      []llmagent.BeforeToolCallback
+	AfterToolCallbacks                       []llmagent.AfterToolCallback
+	BeforeModelCallbacks                     []llmagent.BeforeModelCallback
+	AfterModelCallbacks                      []llmagent.AfterModelCallback
+}
+
+// newCoordinator builds the Booker and Info agents and the coordinator
+// that delegates to them.
+func newCoordinator(s agentSetup) (agent.Agent, error) {
+	bookingAgent, err := llmagent.New(llmagent.Config{
+		Name:                  "Booker",
+		Description:           "Handles flight and hotel bookings. Use your tools for any booking request.",
+		Model:                 s.Models.Booker,
+		GenerateContentConfig: generateContentConfig(),
+		Tools:                 s.BookerTools,
+		BeforeToolCallbacks:   s.BeforeToolCallbacks,
+		AfterToolCallbacks:    s.AfterToolCallbacks,
+		BeforeModelCallbacks:  s.BeforeModelCallbacks,
+		AfterModelCallbacks:   s.AfterModelCallbacks,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating booking agent: %w", err)
+	}
+
+	infoAgent, err := llmagent.New(llmagent.Config{
+		Name:                  "Info",
+		Description:           "Provides general information and answers questions.",
+		Model:                 s.Models.Info,
+		GenerateContentConfig: generateContentConfig(),
+		Tools:                 s.InfoTools,
+		BeforeToolCallbacks:   s.BeforeToolCallbacks,
+		AfterToolCallbacks:    s.AfterToolCallbacks,
+		BeforeModelCallbacks:  s.BeforeModelCallbacks,
+		AfterModelCallbacks:   s.AfterModelCallbacks,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating info agent: %w", err)
+	}
+
+	subAgents, err := orderSubAgents([]agent.Agent{bookingAgent, infoAgent}, *subAgentOrder)
+	if err != nil {
+		return nil, fmt.Errorf("ordering sub-agents: %w", err)
+	}
+
+	var languageProvider func(agent.ReadonlyContext) (string, error)
+	if *autoLanguage {
+		languageProvider = languageInstruction(*languageConfidence)
+	}
+	globalInstruction := withThrottlingGuidance(languageProvider)
+
+	instruction, err := composeInstruction(*instructionFragments, coordinatorFragments)
+	if err != nil {
+		return nil, fmt.Errorf("composing coordinator instruction: %w", err)
+	}
+	instruction += " When a request fits more than one agent, prefer them in this order: " + strings.Join(agentNames(subAgents), ", ") + "."
+
+	coordinator, err := llmagent.New(llmagent.Config{
+		Name:                      "Coordinator",
+		Model:                     s.Models.Coordinator,
+		GenerateContentConfig:     generateContentConfig(),
+		InstructionProvider:       instructionWithVariables(instruction),
+		GlobalInstructionProvider: globalInstruction,
+		BeforeAgentCallbacks:      []agent.BeforeAgentCallback{titleSession},
+		Description:               "Main coordinator.",
+		Tools:                     s.CoordinatorTools,
+		SubAgents:                 subAgents,
+		BeforeToolCallbacks:       s.BeforeToolCallbacks,
+		AfterToolCallbacks:        s.AfterToolCallbacks,
+		BeforeModelCallbacks:      s.BeforeModelCallbacks,
+		AfterModelCallbacks:       s.AfterModelCallbacks,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating coordinator agent: %w", err)
+	}
+	return coordinator, nil
+}
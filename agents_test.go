@@ -0,0 +1,70 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+)
+
+func TestOrderSubAgents(t *testing.T) {
+	var agents []agent.Agent
+	for _, name := range subAgentNames {
+		a, err := llmagent.New(llmagent.Config{Name: name, Model: newMockModel()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		agents = append(agents, a)
+	}
+
+	ordered, err := orderSubAgents(agents, "Info, Booker")
+	if err != nil {
+		t.Fatalf("orderSubAgents: %v", err)
+	}
+	coordinator, err := llmagent.New(llmagent.Config{Name: "Coordinator", Model: newMockModel(), SubAgents: ordered})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := agentNames(coordinator.SubAgents()), []string{"Info", "Booker"}; !slices.Equal(got, want) {
+		t.Errorf("coordinator sub-agents = %v, want %v", got, want)
+	}
+
+	for _, order := range []string{"Info", "Info,Booker,Info", "Booker,Planner"} {
+		if _, err := orderSubAgents(agents, order); err == nil {
+			t.Errorf("orderSubAgents(%q) succeeded, want an error", order)
+		}
+	}
+}
+
+func TestNewCoordinatorRunsEachAgentOnItsModel(t *testing.T) {
+	for _, target := range subAgentNames {
+		t.Run(target, func(t *testing.T) {
+			models := agentModels{
+				Coordinator: newMockModel(calls(transferToolName, map[string]any{"agent_name": target})),
+				Booker:      newMockModel(),
+				Info:        newMockModel(),
+			}
+			coordinator, err := newCoordinator(agentSetup{Models: models})
+			if err != nil {
+				t.Fatalf("newCoordinator: %v", err)
+			}
+			if got := agentNames(coordinator.SubAgents()); !slices.Equal(got, subAgentNames) {
+				t.Errorf("sub-agents = %v, want %v", got, subAgentNames)
+			}
+
+			runTurns(t, coordinator, "hello")
+			requests := map[string]int{}
+			for name, m := range map[string]model.LLM{"Coordinator": models.Coordinator, "Booker": models.Booker, "Info": models.Info} {
+				requests[name] = len(m.(*mockModel).requests)
+			}
+			want := map[string]int{"Coordinator": 1, "Booker": 0, "Info": 0}
+			want[target] = 1
+			if !maps.Equal(requests, want) {
+				t.Errorf("requests per model = %v, want %v", requests, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// transitMinutes is the canned travel time to the airport, including
+// typical traffic, from common departure points.
+var transitMinutes = map[string]int{
+	"city centre":   50,
+	"city center":   50,
+	"downtown":      50,
+	"airport hotel": 10,
+	"suburbs":       75,
+	"train station": 40,
+}
+
+// defaultTransitMinutes is used for departure points missing from
+// transitMinutes.
+const defaultTransitMinutes = 60
+
+// Check-in and security buffers before departure.
+const (
+	domesticBufferMinutes      = 90
+	internationalBufferMinutes = 180
+)
+
+type airportArrivalAdviceArg struct {
+	Confirmation   string `json:"confirmation" jsonschema:"the flight confirmation code"`
+	DeparturePoint string `json:"departure_point" jsonschema:"where the traveler leaves from, e.g. city centre or airport hotel"`
+}
+type airportArrivalAdviceResult struct {
+	Status          string `json:"status"`
+	Cancelled       bool   `json:"cancelled,omitempty"`
+	Departure       string `json:"departure,omitempty"`
+	LeaveBy         string `json:"leave_by,omitempty"`
+	BufferMinutes   int    `json:"buffer_minutes,omitempty"`
+	TransitMinutes  int    `json:"transit_minutes,omitempty"`
+	IsInternational bool   `json:"is_international"`
+	Report          string `json:"report,omitempty"`
+	ErrorMessage    string `json:"error_message,omitempty"`
+}
+
+func airportArrivalAdvice(c tool.Context, arg airportArrivalAdviceArg) airportArrivalAdviceResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return airportArrivalAdviceResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	departure, err := scheduledDeparture(b)
+	if err != nil {
+		return airportArrivalAdviceResult{Status: "error", ErrorMessage: err.Error()}
+	}
+	state := flightStatus(b)
+	if state.Cancelled {
+		return airportArrivalAdviceResult{
+			Status:    "success",
+			Cancelled: true,
+			Report:    fmt.Sprintf("Flight %s has been cancelled; rebook it before planning the trip to the airport.", b.Confirmation),
+		}
+	}
+
+	international := isInternational(b.Origin, b.Destination)
+	buffer := domesticBufferMinutes
+	if international {
+		buffer = internationalBufferMinutes
+	}
+	transit, ok := transitMinutes[strings.ToLower(strings.TrimSpace(arg.DeparturePoint))]
+	if !ok {
+		transit = defaultTransitMinutes
+	}
+	leaveBy := departure.Add(-time.Duration(buffer+transit) * time.Minute)
+
+	report := fmt.Sprintf("Flight %s departs %s. Leave %s by %s (%d min travel, %d min check-in buffer).",
+		b.Confirmation, departure.Format("15:04"), arg.DeparturePoint, leaveBy.Format("15:04"), transit, buffer)
+	if state.Delay > 0 {
+		// Check-in still closes on the original schedule, so a delay does
+		// not move the time to leave.
+		report += fmt.Sprintf(" The flight is delayed by %d minutes, but check-in closes as scheduled.", int(state.Delay.Minutes()))
+	}
+	return airportArrivalAdviceResult{
+		Status:          "success",
+		Departure:       departure.Format("2006-01-02 15:04"),
+		LeaveBy:         leaveBy.Format("2006-01-02 15:04"),
+		BufferMinutes:   buffer,
+		TransitMinutes:  transit,
+		IsInternational: international,
+		Report:          report,
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestAirportArrivalAdvice(t *testing.T) {
+	c := newTestContext(t)
+	domesticCode := bookOnTimeFlight(t, c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	domestic := airportArrivalAdvice(c, airportArrivalAdviceArg{Confirmation: domesticCode, DeparturePoint: "city centre"})
+	code := bookOnTimeFlight(t, c, bookFlightArg{Origin: "London", Destination: "New York", Date: "2025-11-14"})
+	international := airportArrivalAdvice(c, airportArrivalAdviceArg{Confirmation: code, DeparturePoint: "city centre"})
+
+	if domestic.IsInternational || !international.IsInternational {
+		t.Fatalf("IsInternational: domestic %v, international %v", domestic.IsInternational, international.IsInternational)
+	}
+	if international.BufferMinutes <= domestic.BufferMinutes {
+		t.Errorf("international buffer %d min, want more than domestic %d min", international.BufferMinutes, domestic.BufferMinutes)
+	}
+
+	bookings.update(c.SessionID(), code, func(b *booking) { b.Cancelled = true })
+	if got := airportArrivalAdvice(c, airportArrivalAdviceArg{Confirmation: code}); got.Status != "error" {
+		t.Errorf("cancelled flight: Status = %q, want error", got.Status)
+	}
+}
+
+func TestAirportArrivalAdviceForCancelledFlight(t *testing.T) {
+	c := newTestContext(t)
+	b := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Cancelled })
+
+	got := airportArrivalAdvice(c, airportArrivalAdviceArg{Confirmation: b.Confirmation, DeparturePoint: "city centre"})
+	if !got.Cancelled || got.LeaveBy != "" {
+		t.Errorf("got %+v, want the cancellation and no time to leave", got)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/adk/tool"
+)
+
+// baggageFees is the canned per-bag fee schedule by fare class. The n-th
+// entry is the fee for the n-th checked bag; bags beyond the schedule cost
+// the last entry.
+var baggageFees = map[string][]float64{
+	"economy":         {35, 45, 100},
+	"premium economy": {0, 45, 100},
+	"business":        {0, 0, 100},
+}
+
+func baggageFee(fareClass string, bags int) float64 {
+	schedule, ok := baggageFees[fareClass]
+	if !ok {
+		schedule = baggageFees["economy"]
+	}
+	total := 0.0
+	for i := 0; i < bags; i++ {
+		total += schedule[min(i, len(schedule)-1)]
+	}
+	return total
+}
+
+type estimateBaggageFeesArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+	Bags         int    `json:"bags" jsonschema:"the number of checked bags"`
+	Apply        bool   `json:"apply,omitempty" jsonschema:"whether to add the fee to the booking"`
+}
+type estimateBaggageFeesResult struct {
+	Status       string  `json:"status"`
+	FareClass    string  `json:"fare_class,omitempty"`
+	Total        float64 `json:"total"`
+	Report       string  `json:"report,omitempty"`
+	ErrorMessage string  `json:"error_message,omitempty"`
+}
+
+func estimateBaggageFees(c tool.Context, arg estimateBaggageFeesArg) estimateBaggageFeesResult {
+	if arg.Bags < 0 {
+		return estimateBaggageFeesResult{Status: "error", ErrorMessage: "The number of bags must not be negative."}
+	}
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight {
+		return estimateBaggageFeesResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+
+	total := baggageFee(b.FareClass, arg.Bags)
+	report := fmt.Sprintf("%d checked bag(s) in %s on %s cost %.2f.", arg.Bags, b.FareClass, b.Confirmation, total)
+	if arg.Apply {
+		// Reapplying replaces the fee, so only the difference is charged.
+		if delta := total - b.Fees["baggage"]; delta != 0 {
+			spending.record(c.SessionID(), ledgerEntry{
+				Category:     chargeFee,
+				Description:  fmt.Sprintf("Baggage fee for %d bag(s)", arg.Bags),
+				Confirmation: b.Confirmation,
+				Amount:       delta,
+			})
+		}
+		bookings.update(c.SessionID(), b.Confirmation, func(b *booking) {
+			if b.Fees == nil {
+				b.Fees = make(map[string]float64)
+			}
+			b.Fees["baggage"] = total
+		})
+		report += " The fee has been added to the booking."
+	}
+	return estimateBaggageFeesResult{
+		Status:    "success",
+		FareClass: b.FareClass,
+		Total:     total,
+		Report:    report,
+	}
+}
+
+// baggageAllowance is the canned luggage allowance of a fare class.
+type baggageAllowance struct {
+	CabinKg  int `json:"cabin_kg"`
+	PerBagKg int `json:"checked_bag_kg"`
+	FreeBags int `json:"free_checked_bags"`
+	MaxBagCm int `json:"max_bag_dimensions_cm"`
+}
+
+// baggageAllowances is the allowance by fare class.
+var baggageAllowances = map[string]baggageAllowance{
+	"economy":         {CabinKg: 7, PerBagKg: 23, FreeBags: 0, MaxBagCm: 158},
+	"premium economy": {CabinKg: 10, PerBagKg: 23, FreeBags: 1, MaxBagCm: 158},
+	"business":        {CabinKg: 14, PerBagKg: 32, FreeBags: 2, MaxBagCm: 158},
+}
+
+type legAllowance struct {
+	Confirmation string           `json:"confirmation"`
+	Route        string           `json:"route"`
+	FareClass    string           `json:"fare_class"`
+	Allowance    baggageAllowance `json:"allowance"`
+}
+
+type tripBaggageSummaryArg struct{}
+type tripBaggageSummaryResult struct {
+	Status       string            `json:"status"`
+	Allowance    *baggageAllowance `json:"allowance,omitempty"`
+	Legs         []legAllowance    `json:"legs,omitempty"`
+	Report       string            `json:"report,omitempty"`
+	ErrorMessage string            `json:"error_message,omitempty"`
+}
+
+// tripBaggageSummary combines the allowances of every flight in the
+// session. Luggage has to fit every leg, so each limit is the most
+// restrictive across the legs.
+func tripBaggageSummary(c tool.Context, arg tripBaggageSummaryArg) tripBaggageSummaryResult {
+	var legs []legAllowance
+	var combined baggageAllowance
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if b.Kind != kindFlight || b.Cancelled {
+			continue
+		}
+		a, ok := baggageAllowances[b.FareClass]
+		if !ok {
+			a = baggageAllowances["economy"]
+		}
+		if len(legs) == 0 {
+			combined = a
+		} else {
+			combined.CabinKg = min(combined.CabinKg, a.CabinKg)
+			combined.PerBagKg = min(combined.PerBagKg, a.PerBagKg)
+			combined.FreeBags = min(combined.FreeBags, a.FreeBags)
+			combined.MaxBagCm = min(combined.MaxBagCm, a.MaxBagCm)
+		}
+		legs = append(legs, legAllowance{
+			Confirmation: b.Confirmation,
+			Route:        fmt.Sprintf("%s -> %s", b.Origin, b.Destination),
+			FareClass:    b.FareClass,
+			Allowance:    a,
+		})
+	}
+	if len(legs) == 0 {
+		return tripBaggageSummaryResult{Status: "success", Report: "There are no flights in this session."}
+	}
+	return tripBaggageSummaryResult{
+		Status:    "success",
+		Allowance: &combined,
+		Legs:      legs,
+		Report: fmt.Sprintf("Across %d flights: %d kg cabin bag, %d free checked bag(s) of up to %d kg each.",
+			len(legs), combined.CabinKg, combined.FreeBags, combined.PerBagKg),
+	}
+}
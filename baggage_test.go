@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestEstimateBaggageFees(t *testing.T) {
+	c := newTestContext(t)
+	booked := bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	if booked.Status != "success" {
+		t.Fatalf("bookFlight: %s", booked.ErrorMessage)
+	}
+	code := confirmationOf(t, c)
+
+	got := estimateBaggageFees(c, estimateBaggageFeesArg{Confirmation: code, Bags: 2, Apply: true})
+	if got.Status != "success" || got.Total != 80 {
+		t.Fatalf("two economy bags: got %+v, want a total of 80", got)
+	}
+	b, _ := bookings.get(c.SessionID(), code)
+	if b.Fees["baggage"] != 80 {
+		t.Errorf("applied baggage fee = %v, want 80", b.Fees["baggage"])
+	}
+}
+
+func TestTripBaggageSummaryUsesMostRestrictiveLeg(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14", FareClass: "business"})
+	bookFlight(c, bookFlightArg{Origin: "Edinburgh", Destination: "London", Date: "2025-11-17", FareClass: "economy"})
+
+	got := tripBaggageSummary(c, tripBaggageSummaryArg{})
+	if got.Status != "success" || got.Allowance == nil {
+		t.Fatalf("tripBaggageSummary: %+v", got)
+	}
+	if len(got.Legs) != 2 {
+		t.Fatalf("got %d legs, want 2", len(got.Legs))
+	}
+	if want := baggageAllowances["economy"]; *got.Allowance != want {
+		t.Errorf("allowance = %+v, want the economy allowance %+v", *got.Allowance, want)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// arrivalAirport is the canned baggage claim information of a city's main
+// airport.
+type arrivalAirport struct {
+	Code       string
+	Carousels  int
+	ClaimHall  string
+	LostDesk   string
+	LostOnline string
+}
+
+// arrivalAirports is the arrival airport by lower-case city.
+var arrivalAirports = map[string]arrivalAirport{
+	"london":    {Code: "LHR", Carousels: 12, ClaimHall: "Arrivals hall, after passport control", LostDesk: "Baggage services desk beside the carousels", LostOnline: "heathrow.com/baggage"},
+	"paris":     {Code: "CDG", Carousels: 10, ClaimHall: "Level 0 of your terminal", LostDesk: "Lost luggage office in the arrivals hall", LostOnline: "parisaeroport.fr/lost-luggage"},
+	"new york":  {Code: "JFK", Carousels: 14, ClaimHall: "Lower level, before customs", LostDesk: "Airline baggage office next to the carousels", LostOnline: "jfkairport.com/lost-and-found"},
+	"dubai":     {Code: "DXB", Carousels: 16, ClaimHall: "Arrivals, after immigration", LostDesk: "dnata baggage services in the arrivals hall", LostOnline: "dubaiairports.ae/lost-baggage"},
+	"tokyo":     {Code: "HND", Carousels: 8, ClaimHall: "Arrivals floor, before customs", LostDesk: "Baggage service counter at the end of the claim hall", LostOnline: "tokyo-haneda.com/lost-and-found"},
+	"singapore": {Code: "SIN", Carousels: 12, ClaimHall: "Arrivals, level 1", LostDesk: "Lost and found counter near belt 40", LostOnline: "changiairport.com/lost-and-found"},
+	"edinburgh": {Code: "EDI", Carousels: 6, ClaimHall: "Ground floor arrivals", LostDesk: "Swissport baggage desk in the claim hall", LostOnline: "edinburghairport.com/lost-property"},
+}
+
+type getBaggageClaimInfoArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type getBaggageClaimInfoResult struct {
+	Status       string `json:"status"`
+	Airport      string `json:"airport,omitempty"`
+	Carousel     int    `json:"carousel,omitempty"`
+	ClaimArea    string `json:"claim_area,omitempty"`
+	LostBaggage  string `json:"lost_baggage,omitempty"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func getBaggageClaimInfo(c tool.Context, arg getBaggageClaimInfoArg) getBaggageClaimInfoResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return getBaggageClaimInfoResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	airport, ok := arrivalAirports[strings.ToLower(strings.TrimSpace(b.Destination))]
+	if !ok {
+		return getBaggageClaimInfoResult{
+			Status:      "success",
+			LostBaggage: fmt.Sprintf("Report missing bags to %s before leaving the airport.", flightAirline(b)),
+			Report:      fmt.Sprintf("No claim information is available for %s; follow the baggage reclaim signs on arrival.", b.Destination),
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(b.Confirmation + "@" + b.Date))
+	carousel := 1 + int(h.Sum32()%uint32(airport.Carousels))
+	lost := fmt.Sprintf("%s, or %s online; %s handles claims for this flight.", airport.LostDesk, airport.LostOnline, flightAirline(b))
+	return getBaggageClaimInfoResult{
+		Status:      "success",
+		Airport:     airport.Code,
+		Carousel:    carousel,
+		ClaimArea:   airport.ClaimHall,
+		LostBaggage: lost,
+		Report:      fmt.Sprintf("Bags from %s arrive on carousel %d at %s (%s).", b.Confirmation, carousel, airport.Code, airport.ClaimHall),
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestGetBaggageClaimInfo(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	code := confirmationOf(t, c)
+
+	got := getBaggageClaimInfo(c, getBaggageClaimInfoArg{Confirmation: code})
+	if got.Status != "success" || got.Airport != "EDI" {
+		t.Fatalf("got %+v, want claim information for EDI", got)
+	}
+	if got.Carousel < 1 || got.Carousel > arrivalAirports["edinburgh"].Carousels {
+		t.Errorf("carousel %d is outside 1-%d", got.Carousel, arrivalAirports["edinburgh"].Carousels)
+	}
+	if again := getBaggageClaimInfo(c, getBaggageClaimInfoArg{Confirmation: code}); again.Carousel != got.Carousel {
+		t.Errorf("carousel changed from %d to %d", got.Carousel, again.Carousel)
+	}
+	if got.LostBaggage == "" {
+		t.Error("no lost baggage contact given")
+	}
+}
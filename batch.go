@@ -0,0 +1,45 @@
+package main
+
+import "fmt"
+
+// itemOutcome is the result of one item of a tool that acts on several
+// items, so a partial failure tells the model exactly which items failed.
+type itemOutcome struct {
+	Item         string `json:"item"`
+	Status       string `json:"status"`
+	Detail       string `json:"detail,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func itemSucceeded(item, detail string) itemOutcome {
+	return itemOutcome{Item: item, Status: "success", Detail: detail}
+}
+
+func itemFailed(item, format string, args ...any) itemOutcome {
+	return itemOutcome{Item: item, Status: "error", ErrorMessage: fmt.Sprintf(format, args...)}
+}
+
+// batchStatus summarises item outcomes: "success" when every item
+// succeeded, "error" when none did and "partial" otherwise.
+func batchStatus(outcomes []itemOutcome) string {
+	failed := len(failedItems(outcomes))
+	switch {
+	case failed == 0:
+		return "success"
+	case failed == len(outcomes):
+		return "error"
+	default:
+		return "partial"
+	}
+}
+
+// failedItems returns the items that failed, in order.
+func failedItems(outcomes []itemOutcome) []string {
+	var failed []string
+	for _, o := range outcomes {
+		if o.Status != "success" {
+			failed = append(failed, o.Item)
+		}
+	}
+	return failed
+}
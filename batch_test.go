@@ -0,0 +1,32 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBatchStatus(t *testing.T) {
+	ok := itemSucceeded("flight", "CONF_FLIGHT_1")
+	failed := itemFailed("hotel", "%s is full.", "The Strand Grand")
+	if failed.ErrorMessage != "The Strand Grand is full." {
+		t.Errorf("itemFailed message = %q", failed.ErrorMessage)
+	}
+
+	tests := []struct {
+		items      []itemOutcome
+		status     string
+		failedWant []string
+	}{
+		{[]itemOutcome{ok, ok}, "success", nil},
+		{[]itemOutcome{ok, failed, ok}, "partial", []string{"hotel"}},
+		{[]itemOutcome{failed}, "error", []string{"hotel"}},
+	}
+	for _, tt := range tests {
+		if got := batchStatus(tt.items); got != tt.status {
+			t.Errorf("batchStatus(%v) = %q, want %q", tt.items, got, tt.status)
+		}
+		if got := failedItems(tt.items); !slices.Equal(got, tt.failedWant) {
+			t.Errorf("failedItems(%v) = %q, want %q", tt.items, got, tt.failedWant)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// leadTimePrice is the price factor that applies when booking at least
+// MinDays before the travel date.
+type leadTimePrice struct {
+	MinDays int
+	Factor  float64
+}
+
+// leadTimeCurves are canned price-versus-booking-time curves, ordered from
+// the longest lead time down. Flights are cheapest six to sixteen weeks out
+// and climb steeply in the last three weeks; hotels discount unsold rooms
+// shortly before the stay.
+var leadTimeCurves = map[string][]leadTimePrice{
+	kindFlight: {
+		{MinDays: 112, Factor: 1.1},
+		{MinDays: 42, Factor: 0.9},
+		{MinDays: 21, Factor: 1},
+		{MinDays: 7, Factor: 1.25},
+		{MinDays: 0, Factor: 1.5},
+	},
+	kindHotel: {
+		{MinDays: 90, Factor: 1.05},
+		{MinDays: 14, Factor: 1},
+		{MinDays: 3, Factor: 0.95},
+		{MinDays: 0, Factor: 1.1},
+	},
+}
+
+// leadTimeFactor returns the price factor for booking kind days before the
+// travel date.
+func leadTimeFactor(kind string, days int) float64 {
+	for _, p := range leadTimeCurves[kind] {
+		if days >= p.MinDays {
+			return p.Factor
+		}
+	}
+	return 1
+}
+
+// bestBookingLead returns the lead time within the next days at which
+// booking kind is cheapest, preferring the earliest such time.
+func bestBookingLead(kind string, days int) (int, float64) {
+	best, factor := days, leadTimeFactor(kind, days)
+	curve := leadTimeCurves[kind]
+	for i, p := range curve {
+		// The earliest day this price applies, counted as days before
+		// travel.
+		earliest := days
+		if i > 0 && curve[i-1].MinDays-1 < earliest {
+			earliest = curve[i-1].MinDays - 1
+		}
+		if p.MinDays <= earliest && p.Factor < factor {
+			best, factor = earliest, p.Factor
+		}
+	}
+	return best, factor
+}
+
+type bookingStep struct {
+	Priority  int     `json:"priority"`
+	Component string  `json:"component"`
+	BookOn    string  `json:"book_on"`
+	Estimate  float64 `json:"estimated_price"`
+	Reasoning string  `json:"reasoning"`
+}
+
+type optimizeBookingOrderArg struct {
+	Origin      string `json:"origin" jsonschema:"the origin city of the trip"`
+	Destination string `json:"destination" jsonschema:"the destination city of the trip"`
+	FareClass   string `json:"fare_class,omitempty" jsonschema:"the fare class; defaults to economy"`
+	Depart      string `json:"depart" jsonschema:"the departure date, YYYY-MM-DD"`
+	Return      string `json:"return" jsonschema:"the return date, YYYY-MM-DD"`
+}
+type optimizeBookingOrderResult struct {
+	Status       string        `json:"status"`
+	Steps        []bookingStep `json:"steps,omitempty"`
+	Total        float64       `json:"estimated_total"`
+	Report       string        `json:"report,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+func optimizeBookingOrder(c tool.Context, arg optimizeBookingOrderArg) optimizeBookingOrderResult {
+	plan := tripPlan{
+		Origin:      arg.Origin,
+		Destination: arg.Destination,
+		FareClass:   strings.ToLower(strings.TrimSpace(arg.FareClass)),
+		Depart:      arg.Depart,
+		Return:      arg.Return,
+	}
+	cost, err := estimateTripCost(plan)
+	if err != nil {
+		return optimizeBookingOrderResult{Status: "error", ErrorMessage: fmt.Sprintf("Cannot plan this trip: %v.", err)}
+	}
+	today := now().Truncate(24 * time.Hour)
+	depart, _ := time.Parse(dateLayout, strings.TrimSpace(arg.Depart))
+	ret, _ := time.Parse(dateLayout, strings.TrimSpace(arg.Return))
+	if depart.Before(today) {
+		return optimizeBookingOrderResult{Status: "error", ErrorMessage: fmt.Sprintf("The departure date %s has already passed.", arg.Depart)}
+	}
+
+	components := []struct {
+		name, kind string
+		date       time.Time
+		base       float64
+	}{
+		{"outbound flight", kindFlight, depart, cost.Outbound},
+		{"return flight", kindFlight, ret, cost.Return},
+		{fmt.Sprintf("hotel (%d nights)", cost.Nights), kindHotel, depart, cost.Hotel},
+	}
+	var steps []bookingStep
+	var total float64
+	for _, comp := range components {
+		days := int(comp.date.Sub(today).Hours() / 24)
+		current := leadTimeFactor(comp.kind, days)
+		lead, factor := bestBookingLead(comp.kind, days)
+		estimate := math.Round(comp.base*factor*100) / 100
+		total += estimate
+
+		step := bookingStep{Component: comp.name, Estimate: estimate}
+		if lead == days {
+			step.BookOn = today.Format(dateLayout)
+			step.Reasoning = fmt.Sprintf("Prices only rise from here; booking now at %d days out is the cheapest it will be.", days)
+		} else {
+			step.BookOn = comp.date.AddDate(0, 0, -lead).Format(dateLayout)
+			step.Reasoning = fmt.Sprintf("Prices drop about %.0f%% about %d days before; waiting saves roughly %.2f.",
+				(current-factor)/current*100, lead, comp.base*(current-factor))
+		}
+		steps = append(steps, step)
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].BookOn < steps[j].BookOn })
+	for i := range steps {
+		steps[i].Priority = i + 1
+	}
+	return optimizeBookingOrderResult{
+		Status: "success",
+		Steps:  steps,
+		Total:  math.Round(total*100) / 100,
+		Report: fmt.Sprintf("Book the %s first; following this order the trip costs about %.2f.", steps[0].Component, total),
+	}
+}
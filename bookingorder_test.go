@@ -0,0 +1,55 @@
+package main
+
+import "testing"
+
+func TestOptimizeBookingOrder(t *testing.T) {
+	setNow(t, localTime(t, "2025-06-01 09:00"))
+	got := optimizeBookingOrder(newTestContext(t), optimizeBookingOrderArg{
+		Origin: "London", Destination: "New York", Depart: "2025-09-01", Return: "2025-09-08",
+	})
+	if got.Status != "success" {
+		t.Fatalf("optimizeBookingOrder: %s", got.ErrorMessage)
+	}
+	if len(got.Steps) != 3 {
+		t.Fatalf("got %d steps, want 3", len(got.Steps))
+	}
+	// Flights 92 days out are already at their cheapest, while the hotel
+	// gets cheaper two weeks before the stay.
+	want := []struct{ component, bookOn string }{
+		{"outbound flight", "2025-06-01"},
+		{"return flight", "2025-06-01"},
+		{"hotel (7 nights)", "2025-08-19"},
+	}
+	for i, w := range want {
+		s := got.Steps[i]
+		if s.Priority != i+1 || s.Component != w.component || s.BookOn != w.bookOn {
+			t.Errorf("step %d = %+v, want priority %d %s on %s", i, s, i+1, w.component, w.bookOn)
+		}
+	}
+	var total float64
+	for _, s := range got.Steps {
+		total += s.Estimate
+	}
+	if diff := total - got.Total; diff > 0.01 || diff < -0.01 {
+		t.Errorf("total %.2f, want the sum of the steps %.2f", got.Total, total)
+	}
+}
+
+func TestBestBookingLead(t *testing.T) {
+	tests := []struct {
+		kind       string
+		days, want int
+		wantFactor float64
+	}{
+		{kindFlight, 200, 111, 0.9},
+		{kindFlight, 60, 60, 0.9},
+		{kindFlight, 10, 10, 1.25},
+		{kindHotel, 30, 13, 0.95},
+	}
+	for _, tt := range tests {
+		lead, factor := bestBookingLead(tt.kind, tt.days)
+		if lead != tt.want || factor != tt.wantFactor {
+			t.Errorf("bestBookingLead(%s, %d) = %d, %g, want %d, %g", tt.kind, tt.days, lead, factor, tt.want, tt.wantFactor)
+		}
+	}
+}
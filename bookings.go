@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	kindFlight = "flight"
+	kindHotel  = "hotel"
+)
+
+// booking is a flight or hotel booking made during a session.
+type booking struct {
+	Confirmation string
+	Kind         string
+	SessionID    string
+
+	// Flight fields.
+	Origin      string
+	Destination string
+	FareClass   string
+	Seat        string
+	CheckedIn   bool
+
+	// Hotel fields.
+	Location string
+	// PerDiem is the meals and incidentals allowance claimed for the night.
+	PerDiem float64
+
+	Date  string
+	Price float64
+	// Fees are extra charges applied to the booking, keyed by description.
+	Fees map[string]float64
+
+	// Payments records how the booking was paid when split across methods.
+	Payments []payment
+
+	// TripReference is the master reference the booking is grouped under.
+	TripReference string
+	Cancelled     bool
+}
+
+// payment is an amount charged to one payment method.
+type payment struct {
+	Method string  `json:"method"`
+	Amount float64 `json:"amount"`
+}
+
+// bookingStore keeps the bookings of every session in memory.
+type bookingStore struct {
+	mu         sync.Mutex
+	nextFlight int
+	nextHotel  int
+	nextTrip   int
+	byCode     map[string]*booking
+	bySession  map[string][]*booking
+	trips      map[string][]*booking // trip reference -> grouped bookings
+}
+
+func newBookingStore() *bookingStore {
+	return &bookingStore{
+		nextFlight: 12345,
+		nextHotel:  98765,
+		nextTrip:   1001,
+		byCode:     make(map[string]*booking),
+		bySession:  make(map[string][]*booking),
+		trips:      make(map[string][]*booking),
+	}
+}
+
+// bookings is the store used by the booking tools.
+var bookings = newBookingStore()
+
+// add assigns a confirmation code to b, stores it and returns the code.
+func (s *bookingStore) add(b booking) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch b.Kind {
+	case kindFlight:
+		b.Confirmation = fmt.Sprintf("CONF_FLIGHT_%d", s.nextFlight)
+		s.nextFlight++
+	case kindHotel:
+		b.Confirmation = fmt.Sprintf("CONF_HOTEL_%d", s.nextHotel)
+		s.nextHotel++
+	}
+	s.byCode[b.Confirmation] = &b
+	s.bySession[b.SessionID] = append(s.bySession[b.SessionID], &b)
+	return b.Confirmation
+}
+
+// get returns a copy of the session's booking with the given confirmation
+// code.
+func (s *bookingStore) get(sessionID, code string) (booking, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.byCode[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok || b.SessionID != sessionID {
+		return booking{}, false
+	}
+	return b.clone(), true
+}
+
+// update applies fn to the session's booking with the given confirmation
+// code and reports whether the booking was found.
+func (s *bookingStore) update(sessionID, code string, fn func(*booking)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.byCode[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok || b.SessionID != sessionID {
+		return false
+	}
+	fn(b)
+	return true
+}
+
+// forSession returns copies of the session's bookings in booking order.
+func (s *bookingStore) forSession(sessionID string) []booking {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []booking
+	for _, b := range s.bySession[sessionID] {
+		out = append(out, b.clone())
+	}
+	return out
+}
+
+// groupTrip groups the session's active bookings that are not yet part of
+// a trip under a new trip reference. It returns the reference and the
+// grouped confirmation codes, or an empty reference if nothing was left
+// to group.
+func (s *bookingStore) groupTrip(sessionID string) (string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var grouped []*booking
+	for _, b := range s.bySession[sessionID] {
+		if !b.Cancelled && b.TripReference == "" {
+			grouped = append(grouped, b)
+		}
+	}
+	if len(grouped) == 0 {
+		return "", nil
+	}
+	ref := fmt.Sprintf("TRIP_%d", s.nextTrip)
+	s.nextTrip++
+	codes := make([]string, len(grouped))
+	for i, b := range grouped {
+		b.TripReference = ref
+		codes[i] = b.Confirmation
+	}
+	s.trips[ref] = grouped
+	return ref, codes
+}
+
+// trip returns copies of the bookings grouped under the session's trip
+// reference.
+func (s *bookingStore) trip(sessionID, ref string) ([]booking, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grouped, ok := s.trips[strings.ToUpper(strings.TrimSpace(ref))]
+	if !ok || len(grouped) == 0 || grouped[0].SessionID != sessionID {
+		return nil, false
+	}
+	out := make([]booking, len(grouped))
+	for i, b := range grouped {
+		out[i] = b.clone()
+	}
+	return out, true
+}
+
+func (b *booking) clone() booking {
+	c := *b
+	c.Payments = append([]payment(nil), b.Payments...)
+	if b.Fees != nil {
+		c.Fees = make(map[string]float64, len(b.Fees))
+		for k, v := range b.Fees {
+			c.Fees[k] = v
+		}
+	}
+	return c
+}
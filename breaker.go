@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/model"
+)
+
+// errModelUnavailable is returned while the circuit breaker is open.
+var errModelUnavailable = errors.New("the model is temporarily unavailable, please try again shortly")
+
+// breakerModel wraps a model with a circuit breaker. After failureThreshold
+// consecutive failed calls the breaker opens and calls fail fast with
+// errModelUnavailable. Once cooldown has passed, a single trial call is let
+// through (half-open): success closes the breaker, failure opens it again.
+type breakerModel struct {
+	model.LLM
+
+	failureThreshold int
+	cooldown         time.Duration
+	now              func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreakerModel(llm model.LLM, failureThreshold int, cooldown time.Duration) *breakerModel {
+	return &breakerModel{
+		LLM:              llm,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+	}
+}
+
+func (m *breakerModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		if !m.allow() {
+			yield(nil, errModelUnavailable)
+			return
+		}
+		var failed bool
+		defer func() { m.record(failed) }()
+		for resp, err := range m.LLM.GenerateContent(ctx, req, stream) {
+			if err != nil {
+				failed = true
+			}
+			if !yield(resp, err) {
+				return
+			}
+		}
+	}
+}
+
+// allow reports whether a call may go through.
+func (m *breakerModel) allow() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.open {
+		return true
+	}
+	if m.probing || m.now().Sub(m.openedAt) < m.cooldown {
+		return false
+	}
+	log.Printf("model circuit breaker half-open, trying one call")
+	m.probing = true
+	return true
+}
+
+// record updates the breaker with the outcome of a call.
+func (m *breakerModel) record(failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.probing = false
+	if !failed {
+		if m.open {
+			log.Printf("model circuit breaker closed")
+		}
+		m.failures = 0
+		m.open = false
+		return
+	}
+	m.failures++
+	if m.open || m.failures >= m.failureThreshold {
+		if !m.open {
+			log.Printf("model circuit breaker open after %d consecutive failures", m.failures)
+		}
+		m.open = true
+		m.openedAt = m.now()
+	}
+}
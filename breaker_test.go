@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+// switchModel fails every call while failing is set.
+type switchModel struct {
+	failing bool
+	calls   int
+}
+
+func (m *switchModel) Name() string { return "switch" }
+
+func (m *switchModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.calls++
+		if m.failing {
+			yield(nil, errors.New("backend down"))
+			return
+		}
+		yield(&model.LLMResponse{Content: genai.NewContentFromText("ok", genai.RoleModel)}, nil)
+	}
+}
+
+// generate makes one call and returns its error.
+func generate(m model.LLM) error {
+	var err error
+	for _, err = range m.GenerateContent(context.Background(), &model.LLMRequest{}, false) {
+	}
+	return err
+}
+
+func TestBreakerModelOpensAndRecovers(t *testing.T) {
+	inner := &switchModel{failing: true}
+	clock := time.Date(2025, 11, 14, 9, 0, 0, 0, time.UTC)
+	m := newBreakerModel(inner, 3, time.Minute)
+	m.now = func() time.Time { return clock }
+
+	for i := 0; i < 3; i++ {
+		if err := generate(m); err == nil || errors.Is(err, errModelUnavailable) {
+			t.Fatalf("call %d: err = %v, want the backend error", i, err)
+		}
+	}
+	if err := generate(m); !errors.Is(err, errModelUnavailable) {
+		t.Fatalf("after 3 failures: err = %v, want errModelUnavailable", err)
+	}
+	if inner.calls != 3 {
+		t.Errorf("open breaker reached the model: %d calls, want 3", inner.calls)
+	}
+
+	// After the cooldown one trial call goes through and closes the breaker.
+	inner.failing = false
+	clock = clock.Add(time.Minute)
+	if err := generate(m); err != nil {
+		t.Fatalf("trial call after cooldown: %v", err)
+	}
+	if err := generate(m); err != nil {
+		t.Errorf("after recovery: %v", err)
+	}
+}
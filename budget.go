@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"google.golang.org/adk/tool"
+)
+
+// budgetStore keeps the spending limit set for each session.
+type budgetStore struct {
+	mu        sync.Mutex
+	bySession map[string]float64
+}
+
+var budgets = &budgetStore{bySession: make(map[string]float64)}
+
+func (s *budgetStore) set(sessionID string, amount float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bySession[sessionID] = amount
+}
+
+func (s *budgetStore) get(sessionID string) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	amount, ok := s.bySession[sessionID]
+	return amount, ok
+}
+
+// sessionSpent is the total of the session's active bookings, fees
+// included.
+func sessionSpent(sessionID string) float64 {
+	var spent float64
+	for _, b := range bookings.forSession(sessionID) {
+		if !b.Cancelled {
+			spent += b.total()
+		}
+	}
+	return math.Round(spent*100) / 100
+}
+
+// checkBudget reports whether booking price would take the session over its
+// budget. The message is a warning by default; with -hard-budget refuse is
+// true and the booking must not be made.
+func checkBudget(sessionID string, price float64) (message string, refuse bool) {
+	budget, ok := budgets.get(sessionID)
+	if !ok {
+		return "", false
+	}
+	spent := sessionSpent(sessionID)
+	if spent+price <= budget {
+		return "", false
+	}
+	message = fmt.Sprintf("This booking costs %.2f, taking spending to %.2f against a budget of %.2f.", price, spent+price, budget)
+	return message, *hardBudget
+}
+
+type setTripBudgetArg struct {
+	Amount float64 `json:"amount" jsonschema:"the most the user wants to spend on the trip"`
+}
+type setTripBudgetResult struct {
+	Status       string  `json:"status"`
+	Spent        float64 `json:"spent"`
+	Remaining    float64 `json:"remaining"`
+	Report       string  `json:"report,omitempty"`
+	ErrorMessage string  `json:"error_message,omitempty"`
+}
+
+func setTripBudget(c tool.Context, arg setTripBudgetArg) setTripBudgetResult {
+	if arg.Amount <= 0 {
+		return setTripBudgetResult{Status: "error", ErrorMessage: "The budget must be positive."}
+	}
+	budgets.set(c.SessionID(), arg.Amount)
+	spent := sessionSpent(c.SessionID())
+	remaining := math.Round((arg.Amount-spent)*100) / 100
+	return setTripBudgetResult{
+		Status:    "success",
+		Spent:     spent,
+		Remaining: remaining,
+		Report:    fmt.Sprintf("The trip budget is set to %.2f; %.2f is already spent, leaving %.2f.", arg.Amount, spent, remaining),
+	}
+}
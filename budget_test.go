@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestBudgetWarnsOrRefuses(t *testing.T) {
+	c := newTestContext(t)
+	setTripBudget(c, setTripBudgetArg{Amount: 50})
+
+	soft := bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	if soft.Status != "success" || soft.Warning == "" {
+		t.Errorf("without -hard-budget: got %+v, want a booking with a warning", soft)
+	}
+	spent := sessionSpent(c.SessionID())
+
+	setFlag(t, "hard-budget", "true")
+	hard := bookFlight(c, bookFlightArg{Origin: "Edinburgh", Destination: "London", Date: "2025-11-17"})
+	if hard.Status != "error" {
+		t.Errorf("with -hard-budget: got %+v, want the flight refused", hard)
+	}
+	if got := sessionSpent(c.SessionID()); got != spent {
+		t.Errorf("spending went from %.2f to %.2f after a refused booking", spent, got)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// bundleDiscounts is the canned flight+hotel package discount by
+// lower-case destination city.
+var bundleDiscounts = map[string]float64{
+	"paris":     0.12,
+	"new york":  0.15,
+	"dubai":     0.18,
+	"tokyo":     0.1,
+	"singapore": 0.12,
+}
+
+// defaultBundleDiscount applies to destinations missing from
+// bundleDiscounts.
+const defaultBundleDiscount = 0.08
+
+func bundleDiscount(destination string) float64 {
+	if d, ok := bundleDiscounts[strings.ToLower(strings.TrimSpace(destination))]; ok {
+		return d
+	}
+	return defaultBundleDiscount
+}
+
+func discounted(price, discount float64) float64 {
+	return math.Round(price*(1-discount)*100) / 100
+}
+
+type bundleArg struct {
+	Origin      string `json:"origin" jsonschema:"the origin city"`
+	Destination string `json:"destination" jsonschema:"the destination city"`
+	FareClass   string `json:"fare_class,omitempty" jsonschema:"the fare class; defaults to economy"`
+	Depart      string `json:"depart" jsonschema:"the departure and hotel check-in date, YYYY-MM-DD"`
+	Return      string `json:"return" jsonschema:"the return and hotel check-out date, YYYY-MM-DD"`
+}
+
+func (a bundleArg) plan() tripPlan {
+	fareClass := strings.ToLower(strings.TrimSpace(a.FareClass))
+	if fareClass == "" {
+		fareClass = "economy"
+	}
+	return tripPlan{Origin: a.Origin, Destination: a.Destination, FareClass: fareClass, Depart: a.Depart, Return: a.Return}
+}
+
+type suggestBundleResult struct {
+	Status        string   `json:"status"`
+	Candidates    []string `json:"candidates,omitempty"`
+	SeparateTotal float64  `json:"separate_total"`
+	BundleTotal   float64  `json:"bundle_total"`
+	Savings       float64  `json:"savings"`
+	Nights        int      `json:"nights"`
+	Report        string   `json:"report,omitempty"`
+	ErrorMessage  string   `json:"error_message,omitempty"`
+}
+
+// suggestBundle prices a round trip with hotel as a package, discounted
+// against booking each part separately.
+func suggestBundle(c tool.Context, arg bundleArg) suggestBundleResult {
+	for _, city := range []*string{&arg.Origin, &arg.Destination} {
+		location, candidates := resolveCity(*city)
+		if candidates != nil {
+			return suggestBundleResult{
+				Status:     "needs_disambiguation",
+				Candidates: candidates,
+				Report:     disambiguationReport(*city, candidates),
+			}
+		}
+		*city = location
+	}
+	plan := arg.plan()
+	if _, ok := fareClassMultipliers[plan.FareClass]; !ok {
+		return suggestBundleResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown fare class %q. Use economy, premium economy or business.", arg.FareClass)}
+	}
+	cost, err := estimateTripCost(plan)
+	if err != nil {
+		return suggestBundleResult{Status: "error", ErrorMessage: fmt.Sprintf("Cannot price this bundle: %v.", err)}
+	}
+	discount := bundleDiscount(arg.Destination)
+	total := discounted(cost.Total, discount)
+	return suggestBundleResult{
+		Status:        "success",
+		SeparateTotal: cost.Total,
+		BundleTotal:   total,
+		Savings:       math.Round((cost.Total-total)*100) / 100,
+		Nights:        cost.Nights,
+		Report: fmt.Sprintf("Flights and %d hotel nights in %s as a package cost %.2f instead of %.2f, saving %.0f%%.",
+			cost.Nights, arg.Destination, total, cost.Total, discount*100),
+	}
+}
+
+type bookBundleResult struct {
+	Status        string        `json:"status"`
+	Candidates    []string      `json:"candidates,omitempty"`
+	Confirmations []string      `json:"confirmations,omitempty"`
+	Items         []itemOutcome `json:"items,omitempty"`
+	BundleTotal   float64       `json:"bundle_total"`
+	Warning       string        `json:"warning,omitempty"`
+	Report        string        `json:"report,omitempty"`
+	ErrorMessage  string        `json:"error_message,omitempty"`
+}
+
+// bundlePartName describes a part of a bundle for its item outcome.
+func bundlePartName(b booking) string {
+	if b.Kind == kindFlight {
+		return fmt.Sprintf("flight %s -> %s on %s", b.Origin, b.Destination, b.Date)
+	}
+	return fmt.Sprintf("hotel in %s on %s", b.Location, b.Date)
+}
+
+// bookBundle books both flights and a hotel booking for every night of the
+// stay, each at the bundle discount. Each part is reported separately, so a
+// sold-out night does not stop the rest; with -hard-budget a package over
+// the budget is refused as a whole.
+func bookBundle(c tool.Context, arg bundleArg) bookBundleResult {
+	for _, city := range []*string{&arg.Origin, &arg.Destination} {
+		location, candidates := resolveCity(*city)
+		if candidates != nil {
+			return bookBundleResult{
+				Status:     "needs_disambiguation",
+				Candidates: candidates,
+				Report:     disambiguationReport(*city, candidates),
+			}
+		}
+		*city = location
+	}
+	plan := arg.plan()
+	if _, ok := fareClassMultipliers[plan.FareClass]; !ok {
+		return bookBundleResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown fare class %q. Use economy, premium economy or business.", arg.FareClass)}
+	}
+	if _, err := estimateTripCost(plan); err != nil {
+		return bookBundleResult{Status: "error", ErrorMessage: fmt.Sprintf("Cannot book this bundle: %v.", err)}
+	}
+	discount := bundleDiscount(arg.Destination)
+	depart, _ := time.Parse(dateLayout, strings.TrimSpace(plan.Depart))
+	ret, _ := time.Parse(dateLayout, strings.TrimSpace(plan.Return))
+
+	parts := []booking{
+		{Kind: kindFlight, Origin: plan.Origin, Destination: plan.Destination, FareClass: plan.FareClass, Date: plan.Depart,
+			Price: discounted(flightPrice(plan.Origin, plan.Destination, plan.FareClass, plan.Depart), discount)},
+		{Kind: kindFlight, Origin: plan.Destination, Destination: plan.Origin, FareClass: plan.FareClass, Date: plan.Return,
+			Price: discounted(flightPrice(plan.Destination, plan.Origin, plan.FareClass, plan.Return), discount)},
+	}
+	for night := depart; night.Before(ret); night = night.AddDate(0, 0, 1) {
+		date := night.Format(dateLayout)
+		parts = append(parts, booking{Kind: kindHotel, Location: plan.Destination, Date: date,
+			Price: discounted(hotelPrice(plan.Destination, date), discount)})
+	}
+
+	// A night on which every hotel in the city is sold out fails on its
+	// own; the budget is checked against the rest of the package, so that
+	// is booked in full or not at all.
+	soldOut := make([]bool, len(parts))
+	var total float64
+	for i, b := range parts {
+		if b.Kind == kindHotel {
+			night, _ := time.Parse(dateLayout, b.Date)
+			if soldOut[i] = citySoldOut(b.Location, night); soldOut[i] {
+				continue
+			}
+		}
+		total += b.Price
+	}
+	total = math.Round(total*100) / 100
+	warning, refuse := checkBudget(c.SessionID(), total)
+	if refuse {
+		return bookBundleResult{Status: "error", BundleTotal: total, ErrorMessage: warning + " Nothing in the package was booked."}
+	}
+
+	var codes []string
+	var items []itemOutcome
+	for i, b := range parts {
+		if soldOut[i] {
+			items = append(items, itemFailed(bundlePartName(b), "Every hotel in %s is sold out on %s.", b.Location, b.Date))
+			continue
+		}
+		b.SessionID = c.SessionID()
+		code := bookings.add(b)
+		recordBooking(c.SessionID(), code)
+		codes = append(codes, code)
+		items = append(items, itemSucceeded(bundlePartName(b), code))
+	}
+
+	result := bookBundleResult{Status: batchStatus(items), Confirmations: codes, Items: items, BundleTotal: total, Warning: warning}
+	if failed := failedItems(items); len(failed) > 0 {
+		result.ErrorMessage = fmt.Sprintf("Could not book %s.", strings.Join(failed, "; "))
+	}
+	if len(codes) > 0 {
+		result.Report = fmt.Sprintf("Booked the %s package for %.2f. Confirmations: %s", arg.Destination, total, strings.Join(codes, ", "))
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBundleCheaperThanSeparateBookings(t *testing.T) {
+	setNow(t, localTime(t, "2025-11-01 09:00"))
+	c := newTestContext(t)
+	arg := bundleArg{Origin: "London", Destination: "New York", Depart: "2025-11-14", Return: "2025-11-17"}
+
+	suggested := suggestBundle(c, arg)
+	if suggested.Status != "success" {
+		t.Fatalf("suggestBundle: %s", suggested.ErrorMessage)
+	}
+	if suggested.BundleTotal >= suggested.SeparateTotal {
+		t.Errorf("bundle total %.2f is not below the separate total %.2f", suggested.BundleTotal, suggested.SeparateTotal)
+	}
+
+	booked := bookBundle(c, arg)
+	if booked.Status != "success" || len(booked.Confirmations) != 5 {
+		t.Fatalf("bookBundle: got %+v, want two flights and three hotel nights", booked)
+	}
+	separate := flightPrice("London", "New York", "economy", "2025-11-14") + flightPrice("New York", "London", "economy", "2025-11-17")
+	for _, date := range []string{"2025-11-14", "2025-11-15", "2025-11-16"} {
+		separate += hotelPrice("New York", date)
+	}
+	if booked.BundleTotal >= separate {
+		t.Errorf("booked bundle %.2f is not below the separate prices %.2f", booked.BundleTotal, separate)
+	}
+	if math.Abs(booked.BundleTotal-suggested.BundleTotal) > 0.05 {
+		t.Errorf("booked %.2f, but the suggestion quoted %.2f", booked.BundleTotal, suggested.BundleTotal)
+	}
+}
+
+func TestBundleAsksWhichCityIsMeant(t *testing.T) {
+	setNow(t, localTime(t, "2025-11-01 09:00"))
+	c := newTestContext(t)
+	arg := bundleArg{Origin: "London", Destination: "Paris", Depart: "2025-11-14", Return: "2025-11-17"}
+
+	suggested := suggestBundle(c, arg)
+	if suggested.Status != "needs_disambiguation" || len(suggested.Candidates) != 2 {
+		t.Errorf("suggestBundle: got %+v, want the Paris candidates", suggested)
+	}
+	booked := bookBundle(c, arg)
+	if booked.Status != "needs_disambiguation" || len(booked.Candidates) != 2 {
+		t.Errorf("bookBundle: got %+v, want the Paris candidates", booked)
+	}
+	if bs := bookings.forSession(c.SessionID()); len(bs) != 0 {
+		t.Errorf("booked %d parts for an ambiguous destination", len(bs))
+	}
+
+	arg.Destination = "Paris, France"
+	booked = bookBundle(c, arg)
+	if booked.Status != "success" {
+		t.Fatalf("qualified destination: %+v", booked)
+	}
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if b.Kind == kindHotel && b.Location != "Paris" {
+			t.Errorf("hotel booked in %q, want the resolved location Paris", b.Location)
+		}
+	}
+}
+
+func TestHardBudgetRefusesWholeBundle(t *testing.T) {
+	setNow(t, localTime(t, "2025-11-01 09:00"))
+	setFlag(t, "hard-budget", "true")
+	c := newTestContext(t)
+	arg := bundleArg{Origin: "London", Destination: "New York", Depart: "2025-11-14", Return: "2025-11-17"}
+	quote := suggestBundle(c, arg)
+	// Enough for the flights but not the hotel nights as well.
+	budget := discounted(flightPrice("London", "New York", "economy", "2025-11-14")+flightPrice("New York", "London", "economy", "2025-11-17"), bundleDiscount("New York")) + 1
+	setTripBudget(c, setTripBudgetArg{Amount: budget})
+
+	got := bookBundle(c, arg)
+	if got.Status != "error" || len(got.Confirmations) != 0 {
+		t.Fatalf("bundle of %.2f against a budget of %.2f: got %+v, want it refused", quote.BundleTotal, budget, got)
+	}
+	if spent := sessionSpent(c.SessionID()); spent != 0 {
+		t.Errorf("spent %.2f after a refused bundle, want nothing", spent)
+	}
+	if bs := bookings.forSession(c.SessionID()); len(bs) != 0 {
+		t.Errorf("booked %d parts of a refused bundle", len(bs))
+	}
+}
+
+func TestBookBundleReportsSoldOutNight(t *testing.T) {
+	setNow(t, localTime(t, "2025-11-01 09:00"))
+	c := newTestContext(t)
+	soldOut := localTime(t, "2025-11-15 00:00")
+	if !citySoldOut("Dubai", soldOut) {
+		t.Fatal("test assumes every Dubai hotel is full on 2025-11-15")
+	}
+
+	got := bookBundle(c, bundleArg{Origin: "London", Destination: "Dubai", Depart: "2025-11-14", Return: "2025-11-17"})
+	if got.Status != "partial" || len(got.Confirmations) != 4 {
+		t.Fatalf("bookBundle: got %+v, want everything but the sold-out night booked", got)
+	}
+	for _, item := range got.Items {
+		failed := item.Item == "hotel in Dubai on 2025-11-15"
+		if (item.Status == "error") != failed {
+			t.Errorf("%s: status %s", item.Item, item.Status)
+		}
+	}
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if b.Kind == kindHotel && b.Date == "2025-11-15" {
+			t.Errorf("booked %s on the sold-out night", b.Confirmation)
+		}
+	}
+}
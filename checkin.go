@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// checkInWindow is how long before departure online check-in opens.
+const checkInWindow = 24 * time.Hour
+
+type checkInFlightArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type boardingPass struct {
+	Confirmation string `json:"confirmation"`
+	Route        string `json:"route"`
+	Departure    string `json:"departure"`
+	FareClass    string `json:"fare_class"`
+	Seat         string `json:"seat"`
+}
+type checkInFlightResult struct {
+	Status       string        `json:"status"`
+	BoardingPass *boardingPass `json:"boarding_pass,omitempty"`
+	Report       string        `json:"report,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+func checkInFlight(c tool.Context, arg checkInFlightArg) checkInFlightResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return checkInFlightResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	departure, err := scheduledDeparture(b)
+	if err != nil {
+		return checkInFlightResult{Status: "error", ErrorMessage: err.Error()}
+	}
+	current := now()
+	if current.After(departure) {
+		return checkInFlightResult{Status: "error", ErrorMessage: fmt.Sprintf("Flight %s departed at %s.", b.Confirmation, departure.Format("2006-01-02 15:04"))}
+	}
+	if opens := departure.Add(-checkInWindow); current.Before(opens) {
+		return checkInFlightResult{Status: "error", ErrorMessage: fmt.Sprintf("Check-in for %s opens at %s.", b.Confirmation, opens.Format("2006-01-02 15:04"))}
+	}
+
+	seat := b.Seat
+	if seat == "" {
+		seat = assignSeat(b)
+	}
+	if seat == "" {
+		return checkInFlightResult{Status: "error", ErrorMessage: fmt.Sprintf("No seats are left in %s on %s.", b.FareClass, b.Confirmation)}
+	}
+	bookings.update(c.SessionID(), b.Confirmation, func(b *booking) {
+		b.Seat = seat
+		b.CheckedIn = true
+	})
+	return checkInFlightResult{
+		Status: "success",
+		BoardingPass: &boardingPass{
+			Confirmation: b.Confirmation,
+			Route:        fmt.Sprintf("%s -> %s", b.Origin, b.Destination),
+			Departure:    departure.Format("2006-01-02 15:04"),
+			FareClass:    b.FareClass,
+			Seat:         seat,
+		},
+		Report: fmt.Sprintf("Checked in for %s, seat %s.", b.Confirmation, seat),
+	}
+}
+
+// boardingReference encodes the boarding details of a checked-in flight
+// compactly. Unpadded base32 only uses characters from the QR alphanumeric
+// set, so clients can render it as a dense QR code.
+func boardingReference(b booking) string {
+	fields := strings.Join([]string{
+		b.Confirmation,
+		strings.ToUpper(routeKey(b.Origin, b.Destination)),
+		b.Date,
+		b.Seat,
+	}, "/")
+	return "BP1" + base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(fields))
+}
+
+type getBoardingReferenceArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type getBoardingReferenceResult struct {
+	Status       string `json:"status"`
+	Reference    string `json:"reference,omitempty"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func getBoardingReference(c tool.Context, arg getBoardingReferenceArg) getBoardingReferenceResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return getBoardingReferenceResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	if !b.CheckedIn {
+		return getBoardingReferenceResult{Status: "error", ErrorMessage: fmt.Sprintf("%s is not checked in yet; check in first.", b.Confirmation)}
+	}
+	return getBoardingReferenceResult{
+		Status:    "success",
+		Reference: boardingReference(b),
+		Report:    fmt.Sprintf("Boarding reference for %s, seat %s.", b.Confirmation, b.Seat),
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestCheckInFlightWindow(t *testing.T) {
+	c := newTestContext(t)
+	// London to Paris departs at 08:15.
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Paris, France", Date: "2025-11-14"})
+	code := confirmationOf(t, c)
+
+	setNow(t, localTime(t, "2025-11-13 08:00"))
+	if got := checkInFlight(c, checkInFlightArg{Confirmation: code}); got.Status != "error" {
+		t.Errorf("25 hours before departure: Status = %q, want error", got.Status)
+	}
+
+	setNow(t, localTime(t, "2025-11-13 20:00"))
+	got := checkInFlight(c, checkInFlightArg{Confirmation: code})
+	if got.Status != "success" || got.BoardingPass == nil || got.BoardingPass.Seat == "" {
+		t.Fatalf("within the window: got %+v, want a boarding pass with a seat", got)
+	}
+	if b, _ := bookings.get(c.SessionID(), code); !b.CheckedIn {
+		t.Error("booking is not marked checked in")
+	}
+}
+
+func TestGetBoardingReference(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	code := confirmationOf(t, c)
+	if got := getBoardingReference(c, getBoardingReferenceArg{Confirmation: code}); got.Status != "error" {
+		t.Errorf("before check-in: Status = %q, want error", got.Status)
+	}
+
+	setNow(t, localTime(t, "2025-11-13 20:00"))
+	if got := checkInFlight(c, checkInFlightArg{Confirmation: code}); got.Status != "success" {
+		t.Fatalf("checkInFlight: %s", got.ErrorMessage)
+	}
+	first := getBoardingReference(c, getBoardingReferenceArg{Confirmation: code})
+	second := getBoardingReference(c, getBoardingReferenceArg{Confirmation: code})
+	if first.Status != "success" || first.Reference == "" {
+		t.Fatalf("after check-in: got %+v", first)
+	}
+	if first.Reference != second.Reference {
+		t.Errorf("reference changed between calls: %q then %q", first.Reference, second.Reference)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// Checklist item states.
+const (
+	checklistDone        = "done"
+	checklistOutstanding = "outstanding"
+	checklistInfo        = "info"
+	checklistUnavailable = "unavailable"
+)
+
+type checklistItem struct {
+	Flight string `json:"flight"`
+	Item   string `json:"item"`
+	State  string `json:"state"`
+	Detail string `json:"detail"`
+}
+
+type departureChecklistArg struct{}
+type departureChecklistResult struct {
+	Status       string          `json:"status"`
+	Ready        bool            `json:"ready"`
+	Items        []checklistItem `json:"items,omitempty"`
+	Report       string          `json:"report,omitempty"`
+	ErrorMessage string          `json:"error_message,omitempty"`
+}
+
+// departureChecklist builds a readiness report for every upcoming flight in
+// the session, using the check-in, entry requirement and airport arrival
+// tools.
+func departureChecklist(c tool.Context, arg departureChecklistArg) departureChecklistResult {
+	current := now()
+	var items []checklistItem
+	flights := 0
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if b.Kind != kindFlight || b.Cancelled {
+			continue
+		}
+		departure, err := scheduledDeparture(b)
+		if err != nil || departure.Before(current) {
+			continue
+		}
+		flights++
+		add := func(item, state, detail string) {
+			items = append(items, checklistItem{Flight: b.Confirmation, Item: item, State: state, Detail: detail})
+		}
+
+		status := flightStatus(b)
+		if status.Cancelled {
+			add("status", checklistOutstanding, "The flight has been cancelled; rebook it.")
+			continue
+		}
+		if status.Delay > 0 {
+			add("status", checklistInfo, fmt.Sprintf("Delayed by %d minutes; now departs at %s.",
+				int(status.Delay.Minutes()), departure.Add(status.Delay).Format("2006-01-02 15:04")))
+		}
+
+		switch opens := departure.Add(-checkInWindow); {
+		case b.CheckedIn:
+			add("check-in", checklistDone, fmt.Sprintf("Checked in, seat %s.", b.Seat))
+		case current.Before(opens):
+			add("check-in", checklistInfo, fmt.Sprintf("Check-in opens at %s.", opens.Format("2006-01-02 15:04")))
+		default:
+			add("check-in", checklistOutstanding, "Check-in is open; check in now.")
+		}
+
+		if isInternational(b.Origin, b.Destination) {
+			add("documents", checklistInfo, fmt.Sprintf("Make sure your passport is valid for travel to %s.", b.Destination))
+			if country, ok := cityCountries[strings.ToLower(strings.TrimSpace(b.Destination))]; ok {
+				req := getEntryRequirements(c, getEntryRequirementsArg{Country: country})
+				for _, v := range req.Vaccinations {
+					add("health", checklistInfo, v)
+				}
+				for _, a := range req.Advisories {
+					add("health", checklistInfo, a)
+				}
+			} else {
+				add("health", checklistUnavailable, fmt.Sprintf("No entry requirement data for %s; check official sources.", b.Destination))
+			}
+		} else {
+			add("documents", checklistInfo, "Domestic flight; carry photo ID.")
+		}
+
+		add("weather", checklistUnavailable, fmt.Sprintf("No forecast is available for %s; check before packing.", b.Destination))
+
+		if advice := airportArrivalAdvice(c, airportArrivalAdviceArg{Confirmation: b.Confirmation, DeparturePoint: "city centre"}); advice.Status == "success" {
+			add("reminder", checklistInfo, advice.Report)
+		}
+	}
+	if flights == 0 {
+		return departureChecklistResult{Status: "success", Ready: true, Report: "There are no upcoming flights in this session."}
+	}
+
+	outstanding := 0
+	for _, item := range items {
+		if item.State == checklistOutstanding {
+			outstanding++
+		}
+	}
+	report := fmt.Sprintf("All set for %d upcoming flights.", flights)
+	if outstanding > 0 {
+		report = fmt.Sprintf("%d items are outstanding across %d upcoming flights.", outstanding, flights)
+	}
+	return departureChecklistResult{
+		Status: "success",
+		Ready:  outstanding == 0,
+		Items:  items,
+		Report: report,
+	}
+}
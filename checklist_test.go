@@ -0,0 +1,74 @@
+package main
+
+import "testing"
+
+func TestDepartureChecklistShowsCheckInOutstanding(t *testing.T) {
+	c := newTestContext(t)
+	code := bookOnTimeFlight(t, c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+
+	setNow(t, localTime(t, "2025-11-13 20:00"))
+	got := departureChecklist(c, departureChecklistArg{})
+	if got.Ready {
+		t.Errorf("Ready = true with check-in still open, want false")
+	}
+	if state := checklistState(got.Items, code, "check-in"); state != checklistOutstanding {
+		t.Errorf("check-in state = %q, want %q", state, checklistOutstanding)
+	}
+
+	if r := checkInFlight(c, checkInFlightArg{Confirmation: code}); r.Status != "success" {
+		t.Fatalf("checkInFlight: %s", r.ErrorMessage)
+	}
+	got = departureChecklist(c, departureChecklistArg{})
+	if state := checklistState(got.Items, code, "check-in"); state != checklistDone {
+		t.Errorf("after check-in, state = %q, want %q", state, checklistDone)
+	}
+	if !got.Ready {
+		t.Errorf("Ready = false after checking in to a domestic flight: %+v", got.Items)
+	}
+}
+
+func TestDepartureChecklistInternationalFlightCanBeReady(t *testing.T) {
+	c := newTestContext(t)
+	code := bookOnTimeFlight(t, c, bookFlightArg{Origin: "London", Destination: "Marrakesh", Date: "2025-11-14"})
+
+	setNow(t, localTime(t, "2025-11-13 20:00"))
+	if r := checkInFlight(c, checkInFlightArg{Confirmation: code}); r.Status != "success" {
+		t.Fatalf("checkInFlight: %s", r.ErrorMessage)
+	}
+	got := departureChecklist(c, departureChecklistArg{})
+	if state := checklistState(got.Items, code, "health"); state != checklistInfo {
+		t.Errorf("vaccination state = %q, want %q", state, checklistInfo)
+	}
+	if !got.Ready {
+		t.Errorf("Ready = false after checking in to an international flight: %+v", got.Items)
+	}
+}
+
+func TestDepartureChecklistFlagsCancelledFlight(t *testing.T) {
+	setNow(t, localTime(t, "2025-10-01 09:00"))
+	c := newTestContext(t)
+	b := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Cancelled })
+	// Only the cancelled flight stays booked.
+	for _, other := range bookings.forSession(c.SessionID()) {
+		if other.Confirmation != b.Confirmation {
+			bookings.update(c.SessionID(), other.Confirmation, func(b *booking) { b.Cancelled = true })
+		}
+	}
+
+	got := departureChecklist(c, departureChecklistArg{})
+	if got.Ready || checklistState(got.Items, b.Confirmation, "status") != checklistOutstanding {
+		t.Errorf("got %+v, want the cancellation outstanding", got)
+	}
+	if state := checklistState(got.Items, b.Confirmation, "check-in"); state != "" {
+		t.Errorf("check-in listed as %q for a cancelled flight", state)
+	}
+}
+
+func checklistState(items []checklistItem, flight, item string) string {
+	for _, i := range items {
+		if i.Flight == flight && i.Item == item {
+			return i.State
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cityCandidate is one of the places an ambiguous city name can mean.
+type cityCandidate struct {
+	// Name is the qualified name shown to the user.
+	Name string
+	// Location is what the booking is recorded under, matching the keys
+	// of the canned city tables.
+	Location string
+}
+
+// ambiguousCities lists, by lower-case name, cities that share their name
+// with other places and so must be qualified before booking.
+var ambiguousCities = map[string][]cityCandidate{
+	"paris": {
+		{Name: "Paris, France", Location: "Paris"},
+		{Name: "Paris, Texas", Location: "Paris, Texas"},
+	},
+	"springfield": {
+		{Name: "Springfield, Illinois", Location: "Springfield, Illinois"},
+		{Name: "Springfield, Massachusetts", Location: "Springfield, Massachusetts"},
+		{Name: "Springfield, Missouri", Location: "Springfield, Missouri"},
+	},
+	"portland": {
+		{Name: "Portland, Oregon", Location: "Portland, Oregon"},
+		{Name: "Portland, Maine", Location: "Portland, Maine"},
+	},
+}
+
+// resolveCity returns the location to book for a city name. A bare
+// ambiguous name resolves to nothing and returns its candidates instead; a
+// qualified candidate name resolves to its location. Other names are
+// returned unchanged.
+func resolveCity(city string) (string, []string) {
+	name := strings.ToLower(strings.TrimSpace(city))
+	if candidates, ok := ambiguousCities[name]; ok {
+		names := make([]string, len(candidates))
+		for i, c := range candidates {
+			names[i] = c.Name
+		}
+		return "", names
+	}
+	for _, candidates := range ambiguousCities {
+		for _, c := range candidates {
+			if strings.EqualFold(c.Name, strings.TrimSpace(city)) {
+				return c.Location, nil
+			}
+		}
+	}
+	return city, nil
+}
+
+// disambiguationReport is the report of a needs_disambiguation result.
+func disambiguationReport(city string, candidates []string) string {
+	return fmt.Sprintf("%q could be %s. %s", city, strings.Join(candidates, " or "), *disambiguationPrompt)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/adk/tool"
+)
+
+type cabinComfort struct {
+	PitchInches float64  `json:"seat_pitch_in"`
+	WidthInches float64  `json:"seat_width_in"`
+	Recline     string   `json:"recline"`
+	Amenities   []string `json:"amenities"`
+}
+
+// cabinComforts is the canned seat and amenity detail by fare class.
+var cabinComforts = map[string]cabinComfort{
+	"economy": {
+		PitchInches: 31, WidthInches: 17.5, Recline: "standard, about 4 in",
+		Amenities: []string{"seatback screen", "USB power", "complimentary meal"},
+	},
+	"premium economy": {
+		PitchInches: 38, WidthInches: 19, Recline: "extra, about 7 in with leg rest",
+		Amenities: []string{"larger screen", "power outlet", "amenity kit", "priority boarding"},
+	},
+	"business": {
+		PitchInches: 78, WidthInches: 21.5, Recline: "lie-flat bed",
+		Amenities: []string{"direct aisle access", "lounge access", "à la carte dining", "noise-cancelling headphones", "priority boarding"},
+	},
+}
+
+type getComfortDetailsArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type getComfortDetailsResult struct {
+	Status       string        `json:"status"`
+	Cabin        string        `json:"cabin,omitempty"`
+	Comfort      *cabinComfort `json:"comfort,omitempty"`
+	Report       string        `json:"report,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+func getComfortDetails(c tool.Context, arg getComfortDetailsArg) getComfortDetailsResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return getComfortDetailsResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	comfort, ok := cabinComforts[b.FareClass]
+	if !ok {
+		comfort = cabinComforts["economy"]
+	}
+	comfort.Amenities = append([]string(nil), comfort.Amenities...)
+	return getComfortDetailsResult{
+		Status:  "success",
+		Cabin:   b.FareClass,
+		Comfort: &comfort,
+		Report: fmt.Sprintf("%s on %s: %.0f in seat pitch, %.1f in wide, %s recline.",
+			b.FareClass, b.Confirmation, comfort.PitchInches, comfort.WidthInches, comfort.Recline),
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestBusinessHasMorePitchThanEconomy(t *testing.T) {
+	c := newTestContext(t)
+	pitch := func(fareClass string) float64 {
+		t.Helper()
+		bookFlight(c, bookFlightArg{Origin: "London", Destination: "New York", Date: "2025-11-14", FareClass: fareClass})
+		got := getComfortDetails(c, getComfortDetailsArg{Confirmation: confirmationOf(t, c)})
+		if got.Status != "success" || got.Cabin != fareClass {
+			t.Fatalf("%s: got %+v", fareClass, got)
+		}
+		return got.Comfort.PitchInches
+	}
+	economy, business := pitch("economy"), pitch("business")
+	if business <= economy {
+		t.Errorf("business pitch %v in is not more than economy %v in", business, economy)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// compensationRegimes are the passenger-rights regulations by country of
+// departure.
+var compensationRegimes = map[string]string{
+	"united kingdom": "UK261",
+	"france":         "EU261",
+	"germany":        "EU261",
+	"poland":         "EU261",
+	"iceland":        "EU261",
+}
+
+// eu261Threshold is the arrival delay from which EU261 and UK261 pay
+// compensation.
+const eu261Threshold = 3 * time.Hour
+
+// eu261Amount returns the EU261 compensation in euros by flight length,
+// which stands in for the regulation's distance bands. Long-haul
+// compensation is halved for delays under four hours; a cancellation pays
+// in full.
+func eu261Amount(flight time.Duration, state flightState) float64 {
+	switch {
+	case flight <= 2*time.Hour:
+		return 250
+	case flight <= 4*time.Hour:
+		return 400
+	case !state.Cancelled && state.Delay < 4*time.Hour:
+		return 300
+	default:
+		return 600
+	}
+}
+
+type checkCompensationEligibilityArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type checkCompensationEligibilityResult struct {
+	Status       string  `json:"status"`
+	Cancelled    bool    `json:"cancelled"`
+	DelayMinutes int     `json:"delay_minutes"`
+	Regulation   string  `json:"regulation,omitempty"`
+	Eligible     bool    `json:"eligible"`
+	AmountEUR    float64 `json:"amount_eur,omitempty"`
+	Process      string  `json:"process,omitempty"`
+	Report       string  `json:"report,omitempty"`
+	ErrorMessage string  `json:"error_message,omitempty"`
+}
+
+func checkCompensationEligibility(c tool.Context, arg checkCompensationEligibilityArg) checkCompensationEligibilityResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return checkCompensationEligibilityResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	state := flightStatus(b)
+	result := checkCompensationEligibilityResult{Status: "success", Cancelled: state.Cancelled, DelayMinutes: int(state.Delay.Minutes())}
+	disruption := fmt.Sprintf("is %d minutes late", result.DelayMinutes)
+	switch {
+	case state.Cancelled:
+		disruption = "was cancelled"
+	case state.Delay == 0:
+		result.Report = fmt.Sprintf("Flight %s is on time, so it is not eligible for compensation.", b.Confirmation)
+		return result
+	}
+
+	regime, ok := compensationRegimes[cityCountries[strings.ToLower(strings.TrimSpace(b.Origin))]]
+	if !ok {
+		result.Report = fmt.Sprintf("Flight %s %s. No compensation regulation covers departures from %s; ask %s about vouchers or refunds.",
+			b.Confirmation, disruption, b.Origin, flightAirline(b))
+		return result
+	}
+	result.Regulation = regime
+	if !state.Cancelled && state.Delay < eu261Threshold {
+		result.Report = fmt.Sprintf("Flight %s %s; %s only pays compensation from %v, so it is not eligible.",
+			b.Confirmation, disruption, regime, eu261Threshold)
+		return result
+	}
+	result.Eligible = true
+	result.AmountEUR = eu261Amount(flightDuration(b.Origin, b.Destination), state)
+	result.Process = fmt.Sprintf("Claim from %s through its website with confirmation %s, keeping boarding passes and receipts. Compensation does not apply if the disruption was caused by extraordinary circumstances such as severe weather.",
+		flightAirline(b), b.Confirmation)
+	result.Report = fmt.Sprintf("Flight %s %s and may be eligible for %.0f EUR under %s.",
+		b.Confirmation, disruption, result.AmountEUR, regime)
+	return result
+}
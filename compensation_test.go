@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// bookFlightWhere books London to Paris flights on successive days until
+// one satisfies match, and returns its booking.
+func bookFlightWhere(t *testing.T, c *testContext, match func(booking) bool) booking {
+	t.Helper()
+	day := localTime(t, "2025-11-01 00:00")
+	for i := 0; i < 200; i++ {
+		bookFlight(c, bookFlightArg{Origin: "London", Destination: "Paris, France", Date: day.AddDate(0, 0, i).Format(dateLayout)})
+		b, _ := bookings.get(c.SessionID(), confirmationOf(t, c))
+		if match(b) {
+			return b
+		}
+	}
+	t.Fatal("no matching flight found")
+	return booking{}
+}
+
+func TestLongDelayIsEligibleForCompensation(t *testing.T) {
+	c := newTestContext(t)
+	b := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Delay == 5*time.Hour })
+
+	got := checkCompensationEligibility(c, checkCompensationEligibilityArg{Confirmation: b.Confirmation})
+	if got.Status != "success" || !got.Eligible || got.Regulation != "UK261" || got.AmountEUR != 250 {
+		t.Errorf("5 hour delay from London: got %+v, want 250 EUR under UK261", got)
+	}
+}
+
+func TestShortDelayIsNotEligibleForCompensation(t *testing.T) {
+	c := newTestContext(t)
+	b := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Delay == 45*time.Minute })
+
+	got := checkCompensationEligibility(c, checkCompensationEligibilityArg{Confirmation: b.Confirmation})
+	if got.Status != "success" || got.Eligible || got.DelayMinutes != 45 {
+		t.Errorf("45 minute delay: got %+v, want not eligible", got)
+	}
+}
+
+func TestCancelledFlightIsEligibleForCompensation(t *testing.T) {
+	c := newTestContext(t)
+	b := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Cancelled })
+
+	got := checkCompensationEligibility(c, checkCompensationEligibilityArg{Confirmation: b.Confirmation})
+	if got.Status != "success" || !got.Cancelled || !got.Eligible || got.Regulation != "UK261" || got.AmountEUR != 250 {
+		t.Errorf("cancelled flight from London: got %+v, want 250 EUR under UK261", got)
+	}
+}
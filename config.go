@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// modelNamePattern matches Gemini model names like gemini-2.5-flash or
+// gemini-flash-latest.
+var modelNamePattern = regexp.MustCompile(`^gemini-[a-z0-9.-]+$`)
+
+// validateFlags checks the command-line configuration without constructing
+// the model or the agents, returning every problem found.
+func validateFlags() []error {
+	var problems []error
+	for _, m := range []struct{ flag, name string }{
+		{"-model", *modelName},
+		{"-booker-model", agentModelName(*bookerModelName)},
+		{"-info-model", agentModelName(*infoModelName)},
+	} {
+		if !modelNamePattern.MatchString(m.name) {
+			problems = append(problems, fmt.Errorf("%s %q is not a valid Gemini model name", m.flag, m.name))
+		}
+	}
+	if _, err := orderNames(*subAgentOrder, subAgentNames); err != nil {
+		problems = append(problems, fmt.Errorf("-subagent-order: %w", err))
+	}
+	if _, err := composeInstruction(*instructionFragments, coordinatorFragments); err != nil {
+		problems = append(problems, fmt.Errorf("-instruction-fragments: %w", err))
+	}
+	if len(*stopSequences) > maxStopSequences {
+		problems = append(problems, fmt.Errorf("-stop may be given at most %d times, got %d", maxStopSequences, len(*stopSequences)))
+	}
+	if *outputFormat != outputMarkdown && *outputFormat != outputPlain {
+		problems = append(problems, fmt.Errorf("-output must be %s or %s, got %q", outputMarkdown, outputPlain, *outputFormat))
+	}
+	switch *errorVerbosity {
+	case errorsFriendly, errorsDetailed, errorsDebug:
+	default:
+		problems = append(problems, fmt.Errorf("-error-verbosity must be %s, %s or %s, got %q", errorsFriendly, errorsDetailed, errorsDebug, *errorVerbosity))
+	}
+	if *demoLatency < 0 {
+		problems = append(problems, fmt.Errorf("-demo-latency must not be negative, got %v", *demoLatency))
+	}
+	if *breakerFailures < 0 {
+		problems = append(problems, fmt.Errorf("-breaker-failures must not be negative, got %d", *breakerFailures))
+	}
+	if *breakerCooldown < 0 {
+		problems = append(problems, fmt.Errorf("-breaker-cooldown must not be negative, got %v", *breakerCooldown))
+	}
+	if *maxSessions < 0 {
+		problems = append(problems, fmt.Errorf("-max-sessions must not be negative, got %d", *maxSessions))
+	}
+	if *historyTurns < 0 {
+		problems = append(problems, fmt.Errorf("-history-turns must not be negative, got %d", *historyTurns))
+	}
+	if *retryAttempts < 1 {
+		problems = append(problems, fmt.Errorf("-retry-attempts must be at least 1, got %d", *retryAttempts))
+	}
+	if *retryBackoff < 0 {
+		problems = append(problems, fmt.Errorf("-retry-backoff must not be negative, got %v", *retryBackoff))
+	}
+	if *languageConfidence < 0 || *languageConfidence > 1 {
+		problems = append(problems, fmt.Errorf("-language-confidence must be between 0 and 1, got %g", *languageConfidence))
+	}
+	return problems
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateFlags(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, model := range []string{"gemini-2.5-flash", "gemini-flash-latest", "gemini-2.0-flash-001"} {
+			setFlag(t, "model", model)
+			if problems := validateFlags(); len(problems) > 0 {
+				t.Errorf("-model %s: problems %v, want none", model, problems)
+			}
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		setFlag(t, "model", "gpt-4")
+		setFlag(t, "booker-model", "gemini-2.5-flash")
+		setFlag(t, "info-model", "gemini-2.5-flash")
+		setFlag(t, "subagent-order", "Booker")
+		setFlag(t, "history-turns", "-1")
+		problems := validateFlags()
+		if len(problems) != 3 {
+			t.Fatalf("got %d problems %v, want 3", len(problems), problems)
+		}
+		for i, want := range []string{"-model", "-subagent-order", "-history-turns"} {
+			if !strings.Contains(problems[i].Error(), want) {
+				t.Errorf("problem %d = %v, want it to mention %s", i, problems[i], want)
+			}
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"google.golang.org/adk/tool"
+)
+
+// toolCallDeduper caches tool results within a single turn so that a model
+// repeating the same call with identical arguments gets the first result
+// back instead of running the tool again. This also makes the booking tools
+// idempotent within a turn: a duplicate booking request returns the original
+// confirmation rather than booking twice.
+type toolCallDeduper struct {
+	mu       sync.Mutex
+	sessions map[string]*turnCache
+}
+
+// turnCache holds the results of one invocation (turn) of a session.
+type turnCache struct {
+	invocationID string
+	results      map[string]map[string]any
+}
+
+func newToolCallDeduper() *toolCallDeduper {
+	return &toolCallDeduper{sessions: make(map[string]*turnCache)}
+}
+
+// beforeTool returns the cached result for a duplicate call, or nil to let
+// the tool run.
+func (d *toolCallDeduper) beforeTool(ctx tool.Context, t tool.Tool, args map[string]any) (map[string]any, error) {
+	key, err := callKey(t.Name(), args)
+	if err != nil {
+		return nil, nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	cache := d.turn(ctx)
+	if result, ok := cache.results[key]; ok {
+		log.Printf("skipping duplicate call to %s in turn %s, returning cached result", t.Name(), ctx.InvocationID())
+		return result, nil
+	}
+	return nil, nil
+}
+
+// afterTool records a successful result for later duplicates in the turn.
+func (d *toolCallDeduper) afterTool(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error) {
+	if err != nil || result == nil {
+		return nil, nil
+	}
+	key, keyErr := callKey(t.Name(), args)
+	if keyErr != nil {
+		return nil, nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.turn(ctx).results[key] = result
+	return nil, nil
+}
+
+// turn returns the cache for the current invocation, discarding the cache
+// of the session's previous turn. d.mu must be held.
+func (d *toolCallDeduper) turn(ctx tool.Context) *turnCache {
+	cache, ok := d.sessions[ctx.SessionID()]
+	if !ok || cache.invocationID != ctx.InvocationID() {
+		cache = &turnCache{invocationID: ctx.InvocationID(), results: make(map[string]map[string]any)}
+		d.sessions[ctx.SessionID()] = cache
+	}
+	return cache
+}
+
+// callKey identifies a call by tool name and a hash of its arguments.
+// encoding/json sorts map keys, so equal arguments hash equally.
+func callKey(name string, args map[string]any) (string, error) {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return name + ":" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type countArg struct {
+	Item string `json:"item"`
+}
+type countResult struct {
+	Calls int `json:"calls"`
+}
+
+func TestToolCallDeduperRunsDuplicateCallOnce(t *testing.T) {
+	runs := 0
+	counter, err := functiontool.New(functiontool.Config{Name: "count", Description: "counts calls"},
+		func(tool.Context, countArg) countResult {
+			runs++
+			return countResult{Calls: runs}
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deduper := newToolCallDeduper()
+	a, err := llmagent.New(llmagent.Config{
+		Name:                "Booker",
+		Model:               newMockModel(calls("count", map[string]any{"item": "x"}, map[string]any{"item": "x"})),
+		Tools:               []tool.Tool{counter},
+		BeforeToolCallbacks: []llmagent.BeforeToolCallback{deduper.beforeTool},
+		AfterToolCallbacks:  []llmagent.AfterToolCallback{deduper.afterTool},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := functionResponses(runTurns(t, a, "count x twice"), "count")
+	if runs != 1 {
+		t.Errorf("tool ran %d times, want 1", runs)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2", len(responses))
+	}
+	for i, r := range responses {
+		if calls, _ := r["calls"].(float64); calls != 1 {
+			t.Errorf("response %d = %v, want the first call's result", i, r)
+		}
+	}
+}
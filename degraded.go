@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	listBookingsPattern  = regexp.MustCompile(`(?i)\b(list|show|what are)\b.*\bbookings?\b`)
+	cancelBookingPattern = regexp.MustCompile(`(?i)\bcancel\b.*\b(CONF_(?:FLIGHT|HOTEL)_[0-9]+)\b`)
+)
+
+// respondWithoutModel answers the few requests that can be served straight
+// from the booking store while the model is unavailable: listing the
+// session's bookings and cancelling one by confirmation code. It reports
+// false for anything else.
+func respondWithoutModel(sessionID, prompt string) (string, bool) {
+	if m := cancelBookingPattern.FindStringSubmatch(prompt); m != nil {
+		code := strings.ToUpper(m[1])
+		b, ok := bookings.get(sessionID, code)
+		switch {
+		case !ok:
+			return fmt.Sprintf("Unknown confirmation code %s.", code), true
+		case b.Cancelled:
+			return fmt.Sprintf("%s is already cancelled.", code), true
+		}
+		bookings.update(sessionID, code, func(b *booking) { b.Cancelled = true })
+		return fmt.Sprintf("Cancelled %s.", code), true
+	}
+	if listBookingsPattern.MatchString(prompt) {
+		var sb strings.Builder
+		for _, b := range bookings.forSession(sessionID) {
+			if b.Cancelled {
+				continue
+			}
+			switch b.Kind {
+			case kindFlight:
+				fmt.Fprintf(&sb, "\n- %s: flight %s to %s on %s (%s)", b.Confirmation, b.Origin, b.Destination, b.Date, b.FareClass)
+			case kindHotel:
+				fmt.Fprintf(&sb, "\n- %s: hotel in %s on %s", b.Confirmation, b.Location, b.Date)
+			}
+		}
+		if sb.Len() == 0 {
+			return "You have no bookings.", true
+		}
+		return "Your bookings:" + sb.String(), true
+	}
+	return "", false
+}
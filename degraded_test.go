@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/adk/agent/llmagent"
+)
+
+func TestRespondWithoutModelListsBookings(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	flight := confirmationOf(t, c)
+	bookHotel(c, bookHotelArg{Location: "Edinburgh", Date: "2025-11-14"})
+	hotel := confirmationOf(t, c)
+
+	reply, ok := respondWithoutModel(c.SessionID(), "show me my bookings")
+	if !ok {
+		t.Fatal("listing bookings was not answered")
+	}
+	for _, code := range []string{flight, hotel} {
+		if !strings.Contains(reply, code) {
+			t.Errorf("reply %q does not list %s", reply, code)
+		}
+	}
+
+	if reply, _ := respondWithoutModel(c.SessionID(), "please cancel "+hotel); reply != "Cancelled "+hotel+"." {
+		t.Errorf("cancel reply = %q", reply)
+	}
+	if reply, _ := respondWithoutModel(c.SessionID(), "list my bookings"); strings.Contains(reply, hotel) {
+		t.Errorf("cancelled hotel still listed: %q", reply)
+	}
+	if _, ok := respondWithoutModel(c.SessionID(), "book a flight to Rome"); ok {
+		t.Error("a booking request was answered without the model")
+	}
+}
+
+func TestRunAnswersWithoutModelWhenBreakerIsOpen(t *testing.T) {
+	setFlag(t, "rule-fallback", "true")
+	setFlag(t, "degraded-message", "The assistant is unavailable.")
+	m := newBreakerModel(&switchModel{failing: true}, 1, time.Hour)
+	generate(m)
+	a, err := llmagent.New(llmagent.Config{Name: "Coordinator", Model: m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, sessionID := newRunSession(t, a)
+
+	c := newTestContext(t)
+	c.sessionID = sessionID
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	flight := confirmationOf(t, c)
+
+	ctx := context.Background()
+	if reply := run(ctx, r, sessionID, "show me my bookings"); !strings.Contains(reply, flight) {
+		t.Errorf("reply %q does not list %s", reply, flight)
+	}
+	if reply := run(ctx, r, sessionID, "book a flight to Rome"); reply != "The assistant is unavailable." {
+		t.Errorf("reply = %q, want the degraded message", reply)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// demoWordDelayDivisor divides -demo-latency into the pause between
+// printed words, so output streams visibly without taking forever.
+const demoWordDelayDivisor = 10
+
+// demoToolDelay returns a before-tool callback that stalls every tool call
+// by delay, for showing the agent's behavior in slow motion.
+func demoToolDelay(delay time.Duration) func(tool.Context, tool.Tool, map[string]any) (map[string]any, error) {
+	return func(ctx tool.Context, t tool.Tool, args map[string]any) (map[string]any, error) {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+		return nil, nil
+	}
+}
+
+// printSlowly prints text word by word, pausing delay between words.
+func printSlowly(text string, delay time.Duration) {
+	words := strings.SplitAfter(text, " ")
+	for i, word := range words {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		fmt.Print(word)
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDemoToolDelay(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	start := time.Now()
+	result, err := demoToolDelay(delay)(newTestContext(t), nil, nil)
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("tool call delayed %v, want at least %v", elapsed, delay)
+	}
+	if result != nil || err != nil {
+		t.Errorf("got (%v, %v), want the tool to run normally", result, err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+type destinationInfo struct {
+	Currency         string
+	Tipping          string
+	EmergencyNumbers string
+}
+
+// destinations is canned per-country travel information, keyed by
+// lower-case country name.
+var destinations = map[string]destinationInfo{
+	"united kingdom":       {Currency: "GBP", Tipping: "10-12.5% in restaurants if service is not included; not expected in pubs.", EmergencyNumbers: "999 or 112"},
+	"france":               {Currency: "EUR", Tipping: "Service is included; rounding up or leaving a few euros is appreciated.", EmergencyNumbers: "112 (police 17, ambulance 15)"},
+	"united states":        {Currency: "USD", Tipping: "15-20% in restaurants and for taxis; $1-2 per drink at bars.", EmergencyNumbers: "911"},
+	"japan":                {Currency: "JPY", Tipping: "Not customary and may cause confusion.", EmergencyNumbers: "110 (police), 119 (ambulance/fire)"},
+	"germany":              {Currency: "EUR", Tipping: "Round up or add 5-10% in restaurants.", EmergencyNumbers: "112 (police 110)"},
+	"united arab emirates": {Currency: "AED", Tipping: "10-15% in restaurants if service is not included.", EmergencyNumbers: "999 (ambulance 998)"},
+	"singapore":            {Currency: "SGD", Tipping: "Not expected; a 10% service charge is usually added.", EmergencyNumbers: "999 (ambulance 995)"},
+	"thailand":             {Currency: "THB", Tipping: "Small tips of 20-100 THB are appreciated.", EmergencyNumbers: "191 (tourist police 1155)"},
+	"australia":            {Currency: "AUD", Tipping: "Not expected; 10% for exceptional service.", EmergencyNumbers: "000"},
+}
+
+type getDestinationInfoArg struct {
+	Country string `json:"country" jsonschema:"the destination country"`
+}
+type getDestinationInfoResult struct {
+	Status           string `json:"status"`
+	Currency         string `json:"currency"`
+	Tipping          string `json:"tipping"`
+	EmergencyNumbers string `json:"emergency_numbers"`
+	Report           string `json:"report,omitempty"`
+}
+
+func getDestinationInfo(c tool.Context, arg getDestinationInfoArg) getDestinationInfoResult {
+	info, ok := destinations[strings.ToLower(strings.TrimSpace(arg.Country))]
+	if !ok {
+		return getDestinationInfoResult{
+			Status:           "success",
+			Currency:         "unknown",
+			Tipping:          "Check local customs; when in doubt, a tip of around 10% is rarely unwelcome.",
+			EmergencyNumbers: "112 works from most mobile phones worldwide.",
+			Report:           fmt.Sprintf("No specific information is available for %s; returning general advice.", arg.Country),
+		}
+	}
+	return getDestinationInfoResult{
+		Status:           "success",
+		Currency:         info.Currency,
+		Tipping:          info.Tipping,
+		EmergencyNumbers: info.EmergencyNumbers,
+		Report:           fmt.Sprintf("Travel information for %s.", arg.Country),
+	}
+}
+
+type entryRequirements struct {
+	Vaccinations []string
+	Advisories   []string
+}
+
+// healthRequirements is canned per-country vaccination and health advice,
+// keyed by lower-case country name. Countries without an entry have no
+// special requirements.
+var healthRequirements = map[string]entryRequirements{
+	"kenya": {
+		Vaccinations: []string{"Yellow fever (required when arriving from a risk country, recommended for all)"},
+		Advisories:   []string{"Malaria risk outside Nairobi; consider antimalarials.", "Drink bottled or treated water."},
+	},
+	"indonesia": {
+		Vaccinations: []string{"Hepatitis A and typhoid (recommended)"},
+		Advisories:   []string{"Dengue fever occurs; use insect repellent."},
+	},
+	"india": {
+		Vaccinations: []string{"Hepatitis A and typhoid (recommended)", "Polio booster (recommended)"},
+		Advisories:   []string{"Drink bottled or treated water.", "Air quality can be poor in winter."},
+	},
+	"morocco": {
+		Vaccinations: []string{"Hepatitis A (recommended)"},
+	},
+	"thailand": {
+		Vaccinations: []string{"Hepatitis A and typhoid (recommended)"},
+		Advisories:   []string{"Dengue fever occurs; use insect repellent."},
+	},
+}
+
+type getEntryRequirementsArg struct {
+	Country string `json:"country" jsonschema:"the destination country"`
+}
+type getEntryRequirementsResult struct {
+	Status       string   `json:"status"`
+	Vaccinations []string `json:"vaccinations"`
+	Advisories   []string `json:"advisories"`
+	Report       string   `json:"report,omitempty"`
+}
+
+func getEntryRequirements(c tool.Context, arg getEntryRequirementsArg) getEntryRequirementsResult {
+	req, ok := healthRequirements[strings.ToLower(strings.TrimSpace(arg.Country))]
+	if !ok || (len(req.Vaccinations) == 0 && len(req.Advisories) == 0) {
+		return getEntryRequirementsResult{
+			Status:       "success",
+			Vaccinations: []string{},
+			Advisories:   []string{},
+			Report:       fmt.Sprintf("No vaccinations or health advisories are required for %s. Routine vaccinations should be up to date.", arg.Country),
+		}
+	}
+	return getEntryRequirementsResult{
+		Status:       "success",
+		Vaccinations: append([]string{}, req.Vaccinations...),
+		Advisories:   append([]string{}, req.Advisories...),
+		Report:       fmt.Sprintf("Health requirements and advisories for %s.", arg.Country),
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestGetDestinationInfo(t *testing.T) {
+	c := newTestContext(t)
+	if got := getDestinationInfo(c, getDestinationInfoArg{Country: "Japan"}); got.Currency != "JPY" {
+		t.Errorf("Japan: Currency = %q, want JPY", got.Currency)
+	}
+	got := getDestinationInfo(c, getDestinationInfoArg{Country: "Atlantis"})
+	if got.Status != "success" || got.Currency != "unknown" {
+		t.Errorf("Atlantis: got %+v, want a generic success result", got)
+	}
+}
+
+func TestGetEntryRequirements(t *testing.T) {
+	c := newTestContext(t)
+	kenya := getEntryRequirements(c, getEntryRequirementsArg{Country: "Kenya"})
+	if len(kenya.Vaccinations) == 0 || len(kenya.Advisories) == 0 {
+		t.Errorf("Kenya: got %+v, want vaccinations and advisories", kenya)
+	}
+	france := getEntryRequirements(c, getEntryRequirementsArg{Country: "France"})
+	if france.Status != "success" || len(france.Vaccinations) != 0 || len(france.Advisories) != 0 {
+		t.Errorf("France: got %+v, want no requirements", france)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// airportTransferToCity is the canned transfer time from a city's airport
+// to its centre, by lower-case city.
+var airportTransferToCity = map[string]int{
+	"london":    50,
+	"paris":     45,
+	"new york":  60,
+	"dubai":     25,
+	"tokyo":     40,
+	"singapore": 30,
+	"edinburgh": 30,
+	"frankfurt": 20,
+}
+
+// defaultAirportTransferMinutes is the buffer used when there is no
+// transfer data for the destination.
+const defaultAirportTransferMinutes = 60
+
+// Time from landing to leaving the airport, including immigration and
+// baggage claim for international arrivals.
+const (
+	domesticArrivalMinutes      = 30
+	internationalArrivalMinutes = 60
+)
+
+type journeyPart struct {
+	Step    string `json:"step"`
+	Minutes int    `json:"minutes"`
+}
+
+type doorToDoorTimeArg struct {
+	Confirmation       string `json:"confirmation" jsonschema:"the confirmation code of the first flight of the journey"`
+	DeparturePoint     string `json:"departure_point,omitempty" jsonschema:"where the traveler leaves from, e.g. city centre; defaults to city centre"`
+	DestinationAddress string `json:"destination_address" jsonschema:"the address the traveler is going to at the destination"`
+}
+type doorToDoorTimeResult struct {
+	Status       string        `json:"status"`
+	Parts        []journeyPart `json:"parts,omitempty"`
+	TotalMinutes int           `json:"total_minutes"`
+	Report       string        `json:"report,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+// doorToDoorTime adds up a journey starting with the given flight: getting
+// to the airport, the check-in buffer, every connecting flight booked in
+// the session with its layover, leaving the arrival airport, and the
+// transfer to the destination address.
+func doorToDoorTime(c tool.Context, arg doorToDoorTimeArg) doorToDoorTimeResult {
+	first, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || first.Kind != kindFlight || first.Cancelled {
+		return doorToDoorTimeResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	departurePoint := strings.TrimSpace(arg.DeparturePoint)
+	if departurePoint == "" {
+		departurePoint = "city centre"
+	}
+
+	// Follow the connecting flights booked after the first one.
+	legs := []booking{first}
+	flights := activeFlights(c.SessionID())
+	for i, f := range flights {
+		if f.Confirmation != first.Confirmation {
+			continue
+		}
+		for _, next := range flights[i+1:] {
+			if !isConnection(legs[len(legs)-1], next) {
+				break
+			}
+			legs = append(legs, next)
+		}
+	}
+	last := legs[len(legs)-1]
+	international := false
+	for _, leg := range legs {
+		international = international || isInternational(leg.Origin, leg.Destination)
+	}
+
+	var parts []journeyPart
+	add := func(step string, d time.Duration) {
+		parts = append(parts, journeyPart{Step: step, Minutes: int(d.Minutes())})
+	}
+	transit, ok := transitMinutes[strings.ToLower(departurePoint)]
+	if !ok {
+		transit = defaultTransitMinutes
+	}
+	add(fmt.Sprintf("%s to the airport", departurePoint), time.Duration(transit)*time.Minute)
+	buffer := domesticBufferMinutes
+	if international {
+		buffer = internationalBufferMinutes
+	}
+	add("check-in and security", time.Duration(buffer)*time.Minute)
+	for i, leg := range legs {
+		if i > 0 {
+			add(fmt.Sprintf("connection in %s", leg.Origin), connectionTime(leg.Origin))
+		}
+		add(fmt.Sprintf("flight %s -> %s", leg.Origin, leg.Destination), flightDuration(leg.Origin, leg.Destination))
+	}
+	arrival := domesticArrivalMinutes
+	if international {
+		arrival = internationalArrivalMinutes
+	}
+	add("leaving the arrival airport", time.Duration(arrival)*time.Minute)
+	transfer, ok := airportTransferToCity[strings.ToLower(strings.TrimSpace(last.Destination))]
+	if !ok {
+		transfer = defaultAirportTransferMinutes
+	}
+	add(fmt.Sprintf("transfer to %s", arg.DestinationAddress), time.Duration(transfer)*time.Minute)
+
+	total := 0
+	for _, p := range parts {
+		total += p.Minutes
+	}
+	return doorToDoorTimeResult{
+		Status:       "success",
+		Parts:        parts,
+		TotalMinutes: total,
+		Report:       fmt.Sprintf("Door to door from %s to %s takes about %dh%02dm.", departurePoint, arg.DestinationAddress, total/60, total%60),
+	}
+}
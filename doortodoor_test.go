@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDoorToDoorTimeStopsAtReturnLeg(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	outbound := confirmationOf(t, c)
+	// The return leaves the next day, within the connection window, but
+	// goes back to where the journey started.
+	bookFlight(c, bookFlightArg{Origin: "Edinburgh", Destination: "London", Date: "2025-11-15"})
+
+	got := doorToDoorTime(c, doorToDoorTimeArg{Confirmation: outbound, DestinationAddress: "Royal Mile"})
+	if got.Status != "success" {
+		t.Fatalf("doorToDoorTime: %s", got.ErrorMessage)
+	}
+	flights := 0
+	for _, p := range got.Parts {
+		if p.Step == "flight Edinburgh -> London" {
+			t.Errorf("journey follows the return leg: %+v", got.Parts)
+		}
+		if strings.HasPrefix(p.Step, "flight ") {
+			flights++
+		}
+	}
+	if flights != 1 {
+		t.Errorf("got %d flights, want 1: %+v", flights, got.Parts)
+	}
+
+	want := transitMinutes["city centre"] + domesticBufferMinutes +
+		int(flightDuration("London", "Edinburgh").Minutes()) + domesticArrivalMinutes + airportTransferToCity["edinburgh"]
+	if got.TotalMinutes != want {
+		t.Errorf("total = %d minutes, want %d including the flight and transfer", got.TotalMinutes, want)
+	}
+}
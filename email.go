@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// Email body formats.
+const (
+	emailPlain = "plain"
+	emailHTML  = "html"
+)
+
+// itineraryLine describes a booking for the trip email.
+func itineraryLine(b booking) string {
+	switch b.Kind {
+	case kindFlight:
+		line := fmt.Sprintf("Flight %s to %s, %s", b.Origin, b.Destination, b.FareClass)
+		if b.Seat != "" {
+			line += ", seat " + b.Seat
+		}
+		return line
+	case kindHotel:
+		return fmt.Sprintf("Hotel in %s", b.Location)
+	}
+	return b.Kind
+}
+
+type composeTripEmailArg struct {
+	Format string `json:"format,omitempty" jsonschema:"the email body format: plain or html; defaults to plain"`
+}
+type composeTripEmailResult struct {
+	Status       string `json:"status"`
+	Subject      string `json:"subject,omitempty"`
+	Body         string `json:"body,omitempty"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// composeTripEmail writes a ready-to-send summary of the session's
+// itinerary, in date order, with confirmation codes and the total.
+func composeTripEmail(c tool.Context, arg composeTripEmailArg) composeTripEmailResult {
+	format := strings.ToLower(strings.TrimSpace(arg.Format))
+	if format == "" {
+		format = emailPlain
+	}
+	if format != emailPlain && format != emailHTML {
+		return composeTripEmailResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown format %q. Use plain or html.", arg.Format)}
+	}
+
+	var active []booking
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if !b.Cancelled {
+			active = append(active, b)
+		}
+	}
+	if len(active) == 0 {
+		return composeTripEmailResult{Status: "success", Report: "There are no bookings in this session to summarise."}
+	}
+	sort.SliceStable(active, func(i, j int) bool { return active[i].Date < active[j].Date })
+
+	var total float64
+	for _, b := range active {
+		total += b.total()
+	}
+	subject := fmt.Sprintf("Trip itinerary, %s to %s", active[0].Date, active[len(active)-1].Date)
+
+	var sb strings.Builder
+	if format == emailHTML {
+		sb.WriteString("<p>Hi,</p>\n<p>Here is the itinerary for our trip:</p>\n<table>\n")
+		sb.WriteString("<tr><th>Date</th><th>Booking</th><th>Confirmation</th><th>Cost</th></tr>\n")
+		for _, b := range active {
+			fmt.Fprintf(&sb, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%.2f</td></tr>\n",
+				html.EscapeString(b.Date), html.EscapeString(itineraryLine(b)), html.EscapeString(b.Confirmation), b.total())
+		}
+		fmt.Fprintf(&sb, "</table>\n<p><strong>Total: %.2f</strong></p>\n<p>See you there!</p>\n", total)
+	} else {
+		sb.WriteString("Hi,\n\nHere is the itinerary for our trip:\n\n")
+		for _, b := range active {
+			fmt.Fprintf(&sb, "%s  %s\n            Confirmation %s, %.2f\n", b.Date, itineraryLine(b), b.Confirmation, b.total())
+		}
+		fmt.Fprintf(&sb, "\nTotal: %.2f\n\nSee you there!\n", total)
+	}
+	return composeTripEmailResult{
+		Status:  "success",
+		Subject: subject,
+		Body:    sb.String(),
+		Report:  fmt.Sprintf("Composed a %s email covering %d bookings.", format, len(active)),
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComposeTripEmailIncludesEveryConfirmation(t *testing.T) {
+	c := newTestContext(t)
+	var codes []string
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	codes = append(codes, confirmationOf(t, c))
+	bookHotel(c, bookHotelArg{Location: "Edinburgh", Date: "2025-11-14"})
+	codes = append(codes, confirmationOf(t, c))
+	bookFlight(c, bookFlightArg{Origin: "Edinburgh", Destination: "London", Date: "2025-11-15"})
+	codes = append(codes, confirmationOf(t, c))
+
+	for _, format := range []string{emailPlain, emailHTML} {
+		got := composeTripEmail(c, composeTripEmailArg{Format: format})
+		if got.Status != "success" {
+			t.Fatalf("%s: %s", format, got.ErrorMessage)
+		}
+		if got.Subject != "Trip itinerary, 2025-11-14 to 2025-11-15" {
+			t.Errorf("%s subject = %q", format, got.Subject)
+		}
+		for _, code := range codes {
+			if !strings.Contains(got.Body, code) {
+				t.Errorf("%s body is missing %s:\n%s", format, code, got.Body)
+			}
+		}
+	}
+}
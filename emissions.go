@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/adk/tool"
+)
+
+// Emission factors in kg of CO2 per passenger-kilometre.
+const (
+	flightKgPerKm = 0.25
+	trainKgPerKm  = 0.035
+)
+
+// routeKm is the canned distance in kilometres by route, keyed by
+// "origin-destination" in lower case. Distances apply in both directions.
+var routeKm = map[string]float64{
+	"london-paris":     344,
+	"london-edinburgh": 534,
+	"london-frankfurt": 638,
+	"paris-frankfurt":  479,
+	"paris-nice":       686,
+	"new york-boston":  306,
+	"london-new york":  5570,
+	"london-dubai":     5500,
+	"paris-new york":   5840,
+}
+
+// averageFlightSpeedKmh converts a flight duration to an approximate
+// distance on routes missing from routeKm, taxiing and climb included.
+const averageFlightSpeedKmh = 700
+
+// routeDistance returns the distance between two cities from routeKm and
+// the key it was found under.
+func routeDistance(origin, destination string) (float64, string, bool) {
+	key := routeKey(origin, destination)
+	if km, ok := routeKm[key]; ok {
+		return km, key, true
+	}
+	key = routeKey(destination, origin)
+	km, ok := routeKm[key]
+	return km, key, ok
+}
+
+// flightKm returns the distance flown between two cities, estimated from
+// the flight duration when the route is not in routeKm.
+func flightKm(origin, destination string) float64 {
+	if km, _, ok := routeDistance(origin, destination); ok {
+		return km
+	}
+	return flightDuration(origin, destination).Hours() * averageFlightSpeedKmh
+}
+
+// trainRoutes are the routes, keyed like routeKm, with a direct or
+// reasonable rail connection.
+var trainRoutes = map[string]bool{
+	"london-paris":     true,
+	"london-edinburgh": true,
+	"london-frankfurt": true,
+	"paris-frankfurt":  true,
+	"paris-nice":       true,
+	"new york-boston":  true,
+}
+
+type compareModeEmissionsArg struct {
+	Origin      string `json:"origin" jsonschema:"the city the trip starts from"`
+	Destination string `json:"destination" jsonschema:"the city the trip goes to"`
+}
+type compareModeEmissionsResult struct {
+	Status       string  `json:"status"`
+	DistanceKm   float64 `json:"distance_km"`
+	FlightKgCO2  float64 `json:"flight_kg_co2"`
+	TrainKgCO2   float64 `json:"train_kg_co2,omitempty"`
+	SavingKgCO2  float64 `json:"saving_kg_co2,omitempty"`
+	Report       string  `json:"report,omitempty"`
+	ErrorMessage string  `json:"error_message,omitempty"`
+}
+
+// compareModeEmissions estimates the CO2 of flying versus taking the train
+// between two cities.
+func compareModeEmissions(c tool.Context, arg compareModeEmissionsArg) compareModeEmissionsResult {
+	km, key, ok := routeDistance(arg.Origin, arg.Destination)
+	if !ok {
+		return compareModeEmissionsResult{Status: "error", ErrorMessage: fmt.Sprintf("The distance from %s to %s is not known.", arg.Origin, arg.Destination)}
+	}
+	result := compareModeEmissionsResult{
+		Status:      "success",
+		DistanceKm:  km,
+		FlightKgCO2: math.Round(km * flightKgPerKm),
+	}
+	if !trainRoutes[key] {
+		result.Report = fmt.Sprintf("Flying from %s to %s emits about %.0f kg of CO2. There is no train option on this route.", arg.Origin, arg.Destination, result.FlightKgCO2)
+		return result
+	}
+	result.TrainKgCO2 = math.Round(km * trainKgPerKm)
+	result.SavingKgCO2 = result.FlightKgCO2 - result.TrainKgCO2
+	result.Report = fmt.Sprintf("Over %.0f km, flying emits about %.0f kg of CO2 and the train about %.0f kg, saving %.0f kg.",
+		km, result.FlightKgCO2, result.TrainKgCO2, result.SavingKgCO2)
+	return result
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestTrainEmitsLessOnShortRoute(t *testing.T) {
+	c := newTestContext(t)
+	got := compareModeEmissions(c, compareModeEmissionsArg{Origin: "Paris", Destination: "London"})
+	if got.Status != "success" || got.DistanceKm != 344 {
+		t.Fatalf("got %+v, want the 344 km London-Paris route in either direction", got)
+	}
+	if got.TrainKgCO2 == 0 || got.TrainKgCO2 >= got.FlightKgCO2 {
+		t.Errorf("train %.0f kg against flight %.0f kg, want the train lower", got.TrainKgCO2, got.FlightKgCO2)
+	}
+	if got.SavingKgCO2 != got.FlightKgCO2-got.TrainKgCO2 {
+		t.Errorf("saving = %.0f kg, want the difference", got.SavingKgCO2)
+	}
+}
+
+func TestCompareModeEmissionsWithoutTrain(t *testing.T) {
+	c := newTestContext(t)
+	got := compareModeEmissions(c, compareModeEmissionsArg{Origin: "London", Destination: "New York"})
+	if got.Status != "success" || got.TrainKgCO2 != 0 || got.FlightKgCO2 != 1393 {
+		t.Errorf("got %+v, want only the 1393 kg flight", got)
+	}
+	if got := compareModeEmissions(c, compareModeEmissionsArg{Origin: "Bali", Destination: "Boston"}); got.Status != "error" {
+		t.Errorf("unknown route: got status %q, want error", got.Status)
+	}
+}
+
+func TestFlightKmFallsBackToDuration(t *testing.T) {
+	if got := flightKm("Paris", "London"); got != 344 {
+		t.Errorf("flightKm(Paris, London) = %v, want the known 344 km", got)
+	}
+	if got, want := flightKm("New York", "Tokyo"), flightDuration("New York", "Tokyo").Hours()*averageFlightSpeedKmh; got != want {
+		t.Errorf("flightKm(New York, Tokyo) = %v, want %v from the flight duration", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+type expenseLine struct {
+	Category     string  `json:"category"`
+	Description  string  `json:"description"`
+	Date         string  `json:"date"`
+	Confirmation string  `json:"confirmation"`
+	Amount       float64 `json:"amount"`
+}
+
+type generateExpenseReportArg struct{}
+type generateExpenseReportResult struct {
+	Status     string             `json:"status"`
+	Lines      []expenseLine      `json:"lines,omitempty"`
+	Categories map[string]float64 `json:"category_totals,omitempty"`
+	GrandTotal float64            `json:"grand_total"`
+	Spending   []ledgerEntry      `json:"spending_log,omitempty"`
+	Report     string             `json:"report,omitempty"`
+}
+
+// expenseLines itemizes bookings into expense lines, one per booking, per
+// diem and fee.
+func expenseLines(bs []booking) []expenseLine {
+	var lines []expenseLine
+	for _, b := range bs {
+		if b.Cancelled {
+			continue
+		}
+		switch b.Kind {
+		case kindFlight:
+			lines = append(lines, expenseLine{
+				Category:     "Airfare",
+				Description:  fmt.Sprintf("Flight %s to %s (%s)", b.Origin, b.Destination, b.FareClass),
+				Date:         b.Date,
+				Confirmation: b.Confirmation,
+				Amount:       b.Price,
+			})
+		case kindHotel:
+			lines = append(lines, expenseLine{
+				Category:     "Lodging",
+				Description:  fmt.Sprintf("Hotel in %s", b.Location),
+				Date:         b.Date,
+				Confirmation: b.Confirmation,
+				Amount:       b.Price,
+			})
+			if b.PerDiem > 0 {
+				lines = append(lines, expenseLine{
+					Category:     "Meals",
+					Description:  fmt.Sprintf("Per diem in %s", b.Location),
+					Date:         b.Date,
+					Confirmation: b.Confirmation,
+					Amount:       b.PerDiem,
+				})
+			}
+		}
+		fees := make([]string, 0, len(b.Fees))
+		for name := range b.Fees {
+			fees = append(fees, name)
+		}
+		sort.Strings(fees)
+		for _, name := range fees {
+			lines = append(lines, expenseLine{
+				Category:     "Fees",
+				Description:  fmt.Sprintf("%s fee", name),
+				Date:         b.Date,
+				Confirmation: b.Confirmation,
+				Amount:       b.Fees[name],
+			})
+		}
+	}
+	return lines
+}
+
+func generateExpenseReport(c tool.Context, arg generateExpenseReportArg) generateExpenseReportResult {
+	lines := expenseLines(bookings.forSession(c.SessionID()))
+	if len(lines) == 0 {
+		return generateExpenseReportResult{Status: "success", Report: "There are no bookings to report."}
+	}
+
+	categories := make(map[string]float64)
+	var grandTotal float64
+	var sb strings.Builder
+	sb.WriteString("Expense report\n")
+	for _, l := range lines {
+		categories[l.Category] += l.Amount
+		grandTotal += l.Amount
+		fmt.Fprintf(&sb, "%s  %-8s  %-40s  %s  %10.2f\n", l.Date, l.Category, l.Description, l.Confirmation, l.Amount)
+	}
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&sb, "Total %s: %.2f\n", name, categories[name])
+	}
+	fmt.Fprintf(&sb, "Grand total: %.2f\n", grandTotal)
+	spent := spending.forSession(c.SessionID())
+	if len(spent) > 0 {
+		fmt.Fprintf(&sb, "\nSpending log\n%sTotal charged: %.2f\n", renderLedger(spent), ledgerTotal(spent))
+	}
+
+	return generateExpenseReportResult{
+		Status:     "success",
+		Lines:      lines,
+		Categories: categories,
+		GrandTotal: grandTotal,
+		Spending:   spent,
+		Report:     sb.String(),
+	}
+}
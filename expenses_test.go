@@ -0,0 +1,28 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGenerateExpenseReportTotalsMatchPrices(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Paris, France", Date: "2025-11-14"})
+	bookHotel(c, bookHotelArg{Location: "Paris, France", Date: "2025-11-14"})
+	bookHotel(c, bookHotelArg{Location: "Paris, France", Date: "2025-11-15"})
+
+	var want float64
+	for _, b := range bookings.forSession(c.SessionID()) {
+		want += b.Price
+	}
+	got := generateExpenseReport(c, generateExpenseReportArg{})
+	if len(got.Lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(got.Lines))
+	}
+	if math.Abs(got.GrandTotal-want) > 0.001 {
+		t.Errorf("GrandTotal = %.2f, want %.2f", got.GrandTotal, want)
+	}
+	if sum := got.Categories["Airfare"] + got.Categories["Lodging"]; math.Abs(sum-want) > 0.001 {
+		t.Errorf("category totals sum to %.2f, want %.2f", sum, want)
+	}
+}
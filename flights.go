@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the format of booking dates.
+const dateLayout = "2006-01-02"
+
+// now is the clock used by time-dependent tools.
+var now = time.Now
+
+// cityCountries maps lower-case city names to their country.
+var cityCountries = map[string]string{
+	"london":      "united kingdom",
+	"edinburgh":   "united kingdom",
+	"paris":       "france",
+	"nice":        "france",
+	"frankfurt":   "germany",
+	"krakow":      "poland",
+	"reykjavik":   "iceland",
+	"marrakesh":   "morocco",
+	"nairobi":     "kenya",
+	"dubai":       "united arab emirates",
+	"mumbai":      "india",
+	"singapore":   "singapore",
+	"bali":        "indonesia",
+	"tokyo":       "japan",
+	"sydney":      "australia",
+	"new york":    "united states",
+	"boston":      "united states",
+	"los angeles": "united states",
+	"san juan":    "united states",
+}
+
+// departureTimes is the canned local departure time by route, keyed by
+// "origin-destination" in lower case.
+var departureTimes = map[string]string{
+	"london-paris":     "08:15",
+	"london-new york":  "11:40",
+	"london-dubai":     "21:30",
+	"london-edinburgh": "07:05",
+	"paris-london":     "18:20",
+	"new york-london":  "19:00",
+	"new york-tokyo":   "13:25",
+}
+
+// defaultDepartureTime is used for routes missing from departureTimes.
+const defaultDepartureTime = "10:00"
+
+// flightMinutes is the canned flight duration by route, keyed by
+// "origin-destination" in lower case. Durations apply in both directions.
+var flightMinutes = map[string]int{
+	"london-paris":       75,
+	"london-new york":    480,
+	"london-dubai":       420,
+	"london-edinburgh":   80,
+	"london-frankfurt":   95,
+	"paris-new york":     505,
+	"paris-dubai":        400,
+	"frankfurt-new york": 530,
+	"frankfurt-dubai":    375,
+	"dubai-singapore":    440,
+	"dubai-tokyo":        590,
+	"singapore-tokyo":    410,
+	"singapore-sydney":   480,
+	"new york-tokyo":     840,
+	"new york-boston":    75,
+}
+
+// defaultFlightMinutes is used for routes missing from flightMinutes.
+const defaultFlightMinutes = 180
+
+// connectionMinutes is the canned connection time at hub airports, keyed
+// by lower-case city.
+var connectionMinutes = map[string]int{
+	"london":    120,
+	"paris":     100,
+	"frankfurt": 75,
+	"dubai":     120,
+	"singapore": 90,
+	"new york":  150,
+}
+
+// defaultConnectionMinutes is used for cities missing from
+// connectionMinutes.
+const defaultConnectionMinutes = 90
+
+// flightDuration returns the canned duration of a flight on a route.
+func flightDuration(origin, destination string) time.Duration {
+	minutes, ok := flightMinutes[routeKey(origin, destination)]
+	if !ok {
+		minutes, ok = flightMinutes[routeKey(destination, origin)]
+	}
+	if !ok {
+		minutes = defaultFlightMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// connectionTime returns the canned connection time at a city.
+func connectionTime(city string) time.Duration {
+	minutes, ok := connectionMinutes[strings.ToLower(strings.TrimSpace(city))]
+	if !ok {
+		minutes = defaultConnectionMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func routeKey(origin, destination string) string {
+	return strings.ToLower(strings.TrimSpace(origin)) + "-" + strings.ToLower(strings.TrimSpace(destination))
+}
+
+// scheduledDeparture returns the local departure time of a flight booking.
+func scheduledDeparture(b booking) (time.Time, error) {
+	clock, ok := departureTimes[routeKey(b.Origin, b.Destination)]
+	if !ok {
+		clock = defaultDepartureTime
+	}
+	t, err := time.ParseInLocation(dateLayout+" 15:04", b.Date+" "+clock, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid flight date %q: %w", b.Date, err)
+	}
+	return t, nil
+}
+
+// cancellationPercent is the share of flights the canned status reports
+// as cancelled.
+const cancellationPercent = 8
+
+// flightState is the operating status of a booked flight.
+type flightState struct {
+	Cancelled bool
+	// Delay is how late a flight that operates departs and arrives.
+	Delay time.Duration
+}
+
+// flightStatus returns the canned status of a flight. It is derived from
+// the confirmation code and date, so a flight keeps its status across
+// calls; most flights are on time.
+func flightStatus(b booking) flightState {
+	h := fnv.New32a()
+	h.Write([]byte(b.Confirmation + "@" + b.Date))
+	switch n := h.Sum32() % 100; {
+	case n < cancellationPercent:
+		return flightState{Cancelled: true}
+	case n < 60:
+		return flightState{}
+	case n < 80:
+		return flightState{Delay: 45 * time.Minute}
+	case n < 92:
+		return flightState{Delay: 150 * time.Minute}
+	default:
+		return flightState{Delay: 5 * time.Hour}
+	}
+}
+
+// isInternational reports whether the route crosses a border. Routes
+// between cities of unknown country count as international.
+func isInternational(origin, destination string) bool {
+	from, ok := cityCountries[strings.ToLower(strings.TrimSpace(origin))]
+	if !ok {
+		return true
+	}
+	to, ok := cityCountries[strings.ToLower(strings.TrimSpace(destination))]
+	if !ok {
+		return true
+	}
+	return from != to
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// departureTerminals are the canned terminals flights leave from, by
+// lower-case origin city.
+var departureTerminals = map[string][]string{
+	"london":    {"2", "3", "5"},
+	"paris":     {"2E", "2F"},
+	"new york":  {"1", "4", "8"},
+	"dubai":     {"1", "3"},
+	"tokyo":     {"2", "3"},
+	"singapore": {"1", "2", "3"},
+	"edinburgh": {"Main"},
+}
+
+// defaultTerminal is used for cities missing from departureTerminals.
+const defaultTerminal = "1"
+
+// gateAssignmentWindow is how long before departure the gate is announced.
+const gateAssignmentWindow = 3 * time.Hour
+
+// Boarding starts this long before departure, and the gate closes
+// gateClosesBefore departure.
+const (
+	domesticBoardingLead      = 30 * time.Minute
+	internationalBoardingLead = 45 * time.Minute
+	gateClosesBefore          = 15 * time.Minute
+)
+
+type getGateInfoArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type getGateInfoResult struct {
+	Status       string `json:"status"`
+	Terminal     string `json:"terminal,omitempty"`
+	Gate         string `json:"gate,omitempty"`
+	Cancelled    bool   `json:"cancelled,omitempty"`
+	DelayMinutes int    `json:"delay_minutes,omitempty"`
+	Departure    string `json:"departure,omitempty"`
+	BoardingTime string `json:"boarding_time,omitempty"`
+	GateCloses   string `json:"gate_closes,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// getGateInfo reports the terminal, gate and boarding times of a flight as
+// of the current time, moved by any delay; calling it again refreshes the
+// information once the gate is announced.
+func getGateInfo(c tool.Context, arg getGateInfoArg) getGateInfoResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return getGateInfoResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	departure, err := scheduledDeparture(b)
+	if err != nil {
+		return getGateInfoResult{Status: "error", ErrorMessage: err.Error()}
+	}
+	state := flightStatus(b)
+	if state.Cancelled {
+		return getGateInfoResult{
+			Status:    "success",
+			Cancelled: true,
+			Report:    fmt.Sprintf("Flight %s has been cancelled, so no gate will be assigned; look for rebooking options.", b.Confirmation),
+		}
+	}
+	departure = departure.Add(state.Delay)
+
+	h := fnv.New32a()
+	h.Write([]byte(b.Confirmation + "@" + b.Date))
+	sum := h.Sum32()
+	terminal := defaultTerminal
+	if terminals, ok := departureTerminals[strings.ToLower(strings.TrimSpace(b.Origin))]; ok {
+		terminal = terminals[sum%uint32(len(terminals))]
+	}
+	lead := domesticBoardingLead
+	if isInternational(b.Origin, b.Destination) {
+		lead = internationalBoardingLead
+	}
+
+	current := now()
+	result := getGateInfoResult{
+		Status:       "success",
+		DelayMinutes: int(state.Delay.Minutes()),
+		Terminal:     terminal,
+		Departure:    departure.Format("2006-01-02 15:04"),
+		BoardingTime: departure.Add(-lead).Format("2006-01-02 15:04"),
+		GateCloses:   departure.Add(-gateClosesBefore).Format("2006-01-02 15:04"),
+		UpdatedAt:    current.Format("2006-01-02 15:04"),
+	}
+	switch {
+	case current.After(departure):
+		result.Report = fmt.Sprintf("Flight %s departed from terminal %s at %s.", b.Confirmation, terminal, result.Departure)
+	case current.Before(departure.Add(-gateAssignmentWindow)):
+		result.Report = fmt.Sprintf("Flight %s leaves from terminal %s; boarding starts at %s. The gate is announced %v before departure, so check again then.",
+			b.Confirmation, terminal, result.BoardingTime, gateAssignmentWindow)
+	default:
+		result.Gate = fmt.Sprintf("%c%d", 'A'+rune(sum%6), 1+(sum/6)%40)
+		result.Report = fmt.Sprintf("Flight %s boards at gate %s, terminal %s, from %s; the gate closes at %s.",
+			b.Confirmation, result.Gate, terminal, result.BoardingTime, result.GateCloses)
+	}
+	if state.Delay > 0 {
+		result.Report = fmt.Sprintf("Flight %s is delayed by %d minutes. %s", b.Confirmation, result.DelayMinutes, result.Report)
+	}
+	return result
+}
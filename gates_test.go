@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetGateInfo(t *testing.T) {
+	c := newTestContext(t)
+	// London to Paris departs at 08:15 and is international.
+	code := bookOnTimeFlight(t, c, bookFlightArg{Origin: "London", Destination: "Paris, France", Date: "2025-11-14"})
+
+	setNow(t, localTime(t, "2025-11-13 20:00"))
+	early := getGateInfo(c, getGateInfoArg{Confirmation: code})
+	if early.Status != "success" {
+		t.Fatalf("getGateInfo: %s", early.ErrorMessage)
+	}
+	if early.Gate != "" {
+		t.Errorf("gate %q announced 12 hours before departure, want none yet", early.Gate)
+	}
+	if early.Departure != "2025-11-14 08:15" || early.BoardingTime != "2025-11-14 07:30" || early.GateCloses != "2025-11-14 08:00" {
+		t.Errorf("got departure %s, boarding %s, gate closes %s; want 08:15, 07:30 and 08:00",
+			early.Departure, early.BoardingTime, early.GateCloses)
+	}
+
+	setNow(t, localTime(t, "2025-11-14 06:00"))
+	late := getGateInfo(c, getGateInfoArg{Confirmation: code})
+	if late.Gate == "" {
+		t.Errorf("no gate announced 2 hours before departure: %s", late.Report)
+	}
+	if late.Terminal != early.Terminal {
+		t.Errorf("terminal changed from %s to %s", early.Terminal, late.Terminal)
+	}
+}
+
+func TestGetGateInfoFollowsFlightStatus(t *testing.T) {
+	setNow(t, localTime(t, "2025-10-01 09:00"))
+	c := newTestContext(t)
+	delayed := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Delay == 45*time.Minute })
+	got := getGateInfo(c, getGateInfoArg{Confirmation: delayed.Confirmation})
+	if got.DelayMinutes != 45 || got.Departure != delayed.Date+" 09:00" || got.BoardingTime != delayed.Date+" 08:15" {
+		t.Errorf("45 minute delay on the 08:15: got %+v, want departure 09:00 and boarding 08:15", got)
+	}
+
+	cancelled := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Cancelled })
+	got = getGateInfo(c, getGateInfoArg{Confirmation: cancelled.Confirmation})
+	if got.Status != "success" || !got.Cancelled || got.Terminal != "" {
+		t.Errorf("cancelled flight: got %+v, want it reported cancelled without a terminal", got)
+	}
+}
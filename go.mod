@@ -3,6 +3,7 @@ module taprom_agent
 go 1.25
 
 require (
+	github.com/abadojack/whatlanggo v1.0.1
 	github.com/joho/godotenv v1.5.1
 	google.golang.org/genai v1.20.0
 )
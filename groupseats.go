@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// adjacentBlock returns n free seats side by side in one row, without the
+// aisle between them, or nil when no row has such a block.
+func (m seatMap) adjacentBlock(n int) []string {
+	l := m.Layout
+	blocks := []string{l.Letters[:l.AisleAfter], l.Letters[l.AisleAfter:]}
+	for row := l.FirstRow; row <= l.LastRow; row++ {
+		for _, block := range blocks {
+			var run []string
+			for _, letter := range block {
+				seat := fmt.Sprintf("%d%c", row, letter)
+				if m.Occupied[seat] {
+					run = nil
+					continue
+				}
+				run = append(run, seat)
+				if len(run) == n {
+					return run
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sameRow returns n free seats in one row, possibly across the aisle, or
+// nil when no row has that many.
+func (m seatMap) sameRow(n int) []string {
+	l := m.Layout
+	for row := l.FirstRow; row <= l.LastRow; row++ {
+		var seats []string
+		for _, letter := range l.Letters {
+			if seat := fmt.Sprintf("%d%c", row, letter); !m.Occupied[seat] {
+				seats = append(seats, seat)
+			}
+		}
+		if len(seats) >= n {
+			return seats[:n]
+		}
+	}
+	return nil
+}
+
+type arrangeGroupSeatsArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+	PartySize    int    `json:"party_size" jsonschema:"how many travellers should sit together"`
+}
+type arrangeGroupSeatsResult struct {
+	Status       string   `json:"status"`
+	Arrangement  string   `json:"arrangement,omitempty"`
+	Seats        []string `json:"seats,omitempty"`
+	Report       string   `json:"report,omitempty"`
+	ErrorMessage string   `json:"error_message,omitempty"`
+}
+
+// arrangeGroupSeats suggests seats for a party on a flight: side by side
+// if possible, then in one row across the aisle, and otherwise the first
+// free seats in row order.
+func arrangeGroupSeats(c tool.Context, arg arrangeGroupSeatsArg) arrangeGroupSeatsResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return arrangeGroupSeatsResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	if arg.PartySize < 1 {
+		return arrangeGroupSeatsResult{Status: "error", ErrorMessage: "The party size must be at least 1."}
+	}
+	m := seatMapFor(b)
+	free := m.free()
+	if len(free) < arg.PartySize {
+		return arrangeGroupSeatsResult{
+			Status:       "error",
+			ErrorMessage: fmt.Sprintf("Only %d seats are free in %s, not enough for a party of %d.", len(free), m.Cabin, arg.PartySize),
+		}
+	}
+
+	result := arrangeGroupSeatsResult{Status: "success"}
+	if seats := m.adjacentBlock(arg.PartySize); seats != nil {
+		result.Arrangement = "adjacent"
+		result.Seats = seats
+		result.Report = fmt.Sprintf("The party of %d can sit together in %s.", arg.PartySize, strings.Join(seats, ", "))
+	} else if seats := m.sameRow(arg.PartySize); seats != nil {
+		result.Arrangement = "same row"
+		result.Seats = seats
+		result.Report = fmt.Sprintf("No %d adjacent seats are free; %s are in the same row.", arg.PartySize, strings.Join(result.Seats, ", "))
+	} else {
+		result.Arrangement = "split"
+		result.Seats = free[:arg.PartySize]
+		result.Report = fmt.Sprintf("The party of %d cannot sit in one row; the closest free seats are %s.", arg.PartySize, strings.Join(result.Seats, ", "))
+	}
+	return result
+}
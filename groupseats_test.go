@@ -0,0 +1,57 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGroupSeatArrangements(t *testing.T) {
+	business := cabinLayouts["business"]
+	empty := seatMap{Cabin: "business", Layout: business, Occupied: map[string]bool{}}
+	if got := empty.adjacentBlock(2); !slices.Equal(got, []string{"1A", "1C"}) {
+		t.Errorf("adjacentBlock(2) = %v, want [1A 1C]", got)
+	}
+	// Business seats are in pairs, so three never sit side by side.
+	if got := empty.adjacentBlock(3); got != nil {
+		t.Errorf("adjacentBlock(3) = %v, want nil", got)
+	}
+	if got := empty.sameRow(3); !slices.Equal(got, []string{"1A", "1C", "1D"}) {
+		t.Errorf("sameRow(3) = %v, want [1A 1C 1D]", got)
+	}
+
+	// Only the window seats are free.
+	windows := seatMap{Cabin: "business", Layout: business, Occupied: map[string]bool{}}
+	for _, seat := range business.seats() {
+		if seat[len(seat)-1] != 'A' && seat[len(seat)-1] != 'F' {
+			windows.Occupied[seat] = true
+		}
+	}
+	if got := windows.sameRow(3); got != nil {
+		t.Errorf("sameRow(3) with two free seats a row = %v, want nil", got)
+	}
+}
+
+func TestArrangeGroupSeatsForParty(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "New York", Date: "2025-11-14"})
+	code := confirmationOf(t, c)
+
+	got := arrangeGroupSeats(c, arrangeGroupSeatsArg{Confirmation: code, PartySize: 3})
+	if got.Status != "success" || got.Arrangement != "adjacent" || len(got.Seats) != 3 {
+		t.Fatalf("party of 3 in economy: got %+v, want three adjacent seats", got)
+	}
+	b, _ := bookings.get(c.SessionID(), code)
+	m := seatMapFor(b)
+	for _, seat := range got.Seats {
+		if m.Occupied[seat] {
+			t.Errorf("suggested seat %s is occupied", seat)
+		}
+	}
+
+	// Five can never share a four-across business row.
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "New York", Date: "2025-11-14", FareClass: "business"})
+	split := arrangeGroupSeats(c, arrangeGroupSeatsArg{Confirmation: confirmationOf(t, c), PartySize: 5})
+	if split.Status != "success" || split.Arrangement != "split" || len(split.Seats) != 5 {
+		t.Errorf("party of 5 in business: got %+v, want a split arrangement", split)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"iter"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/genai"
+)
+
+// testContext is a tool.Context for calling tool functions directly. It
+// answers the session lookups the tools make; the remaining methods of the
+// embedded interface are nil and panic if called.
+type testContext struct {
+	tool.Context
+	ctx       context.Context
+	sessionID string
+	userID    string
+	state     *mapState
+	// userContent is the user input of the turn.
+	userContent *genai.Content
+}
+
+// newTestContext returns a context whose session ID is unique to the test,
+// so the package-level booking stores do not leak between tests.
+func newTestContext(t *testing.T) *testContext {
+	return &testContext{
+		ctx:       context.Background(),
+		sessionID: t.Name(),
+		userID:    "user-" + t.Name(),
+		state:     &mapState{values: make(map[string]any)},
+	}
+}
+
+func (c *testContext) SessionID() string                    { return c.sessionID }
+func (c *testContext) UserID() string                       { return c.userID }
+func (c *testContext) AppName() string                      { return "test" }
+func (c *testContext) AgentName() string                    { return "Booker" }
+func (c *testContext) InvocationID() string                 { return "invocation" }
+func (c *testContext) UserContent() *genai.Content          { return c.userContent }
+func (c *testContext) State() session.State                 { return c.state }
+func (c *testContext) ReadonlyState() session.ReadonlyState { return c.state }
+func (c *testContext) Deadline() (time.Time, bool)          { return c.ctx.Deadline() }
+func (c *testContext) Done() <-chan struct{}                { return c.ctx.Done() }
+func (c *testContext) Err() error                           { return c.ctx.Err() }
+func (c *testContext) Value(key any) any                    { return c.ctx.Value(key) }
+
+// mapState is a session.State backed by a map.
+type mapState struct {
+	values map[string]any
+}
+
+func (s *mapState) Get(key string) (any, error) {
+	v, ok := s.values[key]
+	if !ok {
+		return nil, session.ErrStateKeyNotExist
+	}
+	return v, nil
+}
+
+func (s *mapState) Set(key string, value any) error {
+	s.values[key] = value
+	return nil
+}
+
+func (s *mapState) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for k, v := range s.values {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// mockModel is a model.LLM that replies with canned contents in order, then
+// with "done" once they run out. It records every request it receives.
+type mockModel struct {
+	mu        sync.Mutex
+	responses []*genai.Content
+	requests  []*model.LLMRequest
+}
+
+func newMockModel(responses ...*genai.Content) *mockModel {
+	return &mockModel{responses: responses}
+}
+
+func (m *mockModel) Name() string { return "mock" }
+
+func (m *mockModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	m.mu.Lock()
+	m.requests = append(m.requests, req)
+	content := genai.NewContentFromText("done", genai.RoleModel)
+	if len(m.responses) > 0 {
+		content, m.responses = m.responses[0], m.responses[1:]
+	}
+	m.mu.Unlock()
+	return func(yield func(*model.LLMResponse, error) bool) {
+		yield(&model.LLMResponse{Content: content}, nil)
+	}
+}
+
+// calls returns a model content calling name once per args.
+func calls(name string, args ...map[string]any) *genai.Content {
+	content := &genai.Content{Role: genai.RoleModel}
+	for _, a := range args {
+		content.Parts = append(content.Parts, genai.NewPartFromFunctionCall(name, a))
+	}
+	return content
+}
+
+// runTurns runs each prompt as a turn of one session against a and returns
+// the events of every turn.
+func runTurns(t *testing.T, a agent.Agent, prompts ...string) []*session.Event {
+	t.Helper()
+	ctx := context.Background()
+	service := session.InMemoryService()
+	r, err := runner.New(runner.Config{AppName: "test", Agent: a, SessionService: service})
+	if err != nil {
+		t.Fatalf("runner.New: %v", err)
+	}
+	created, err := service.Create(ctx, &session.CreateRequest{AppName: "test", UserID: "user"})
+	if err != nil {
+		t.Fatalf("creating session: %v", err)
+	}
+	var events []*session.Event
+	for _, prompt := range prompts {
+		for event, err := range r.Run(ctx, "user", created.Session.ID(), genai.NewContentFromText(prompt, genai.RoleUser), agent.RunConfig{}) {
+			if err != nil {
+				t.Fatalf("turn %q: %v", prompt, err)
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// newRunSession returns a runner for a and a session of the user run sends
+// its turns as.
+func newRunSession(t *testing.T, a agent.Agent) (*runner.Runner, string) {
+	t.Helper()
+	service := session.InMemoryService()
+	r, err := runner.New(runner.Config{AppName: "test", Agent: a, SessionService: service})
+	if err != nil {
+		t.Fatalf("runner.New: %v", err)
+	}
+	created, err := service.Create(context.Background(), &session.CreateRequest{AppName: "test", UserID: "user1234"})
+	if err != nil {
+		t.Fatalf("creating session: %v", err)
+	}
+	return r, created.Session.ID()
+}
+
+// bookOnTimeFlight books arg until the flight's canned status is on time,
+// cancelling the other attempts, and returns its confirmation code. The
+// status hashes the confirmation code, which depends on test order.
+func bookOnTimeFlight(t *testing.T, c *testContext, arg bookFlightArg) string {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		bookFlight(c, arg)
+		code := confirmationOf(t, c)
+		b, _ := bookings.get(c.SessionID(), code)
+		if flightStatus(b) == (flightState{}) {
+			return code
+		}
+		bookings.update(c.SessionID(), code, func(b *booking) { b.Cancelled = true })
+	}
+	t.Fatalf("no on-time flight %s -> %s on %s", arg.Origin, arg.Destination, arg.Date)
+	return ""
+}
+
+// functionResponses returns the responses to calls of name among events.
+func functionResponses(events []*session.Event, name string) []map[string]any {
+	var responses []map[string]any
+	for _, e := range events {
+		if e.Content == nil {
+			continue
+		}
+		for _, p := range e.Content.Parts {
+			if p.FunctionResponse != nil && p.FunctionResponse.Name == name {
+				responses = append(responses, p.FunctionResponse.Response)
+			}
+		}
+	}
+	return responses
+}
+
+// confirmationOf returns the confirmation code of the session's most
+// recent booking.
+func confirmationOf(t *testing.T, c *testContext) string {
+	t.Helper()
+	bs := bookings.forSession(c.SessionID())
+	if len(bs) == 0 {
+		t.Fatal("no bookings in session")
+	}
+	return bs[len(bs)-1].Confirmation
+}
+
+// setFlag sets a command-line flag for the rest of the test.
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+	f := flag.Lookup(name)
+	if f == nil {
+		t.Fatalf("no flag -%s", name)
+	}
+	old := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatalf("setting -%s: %v", name, err)
+	}
+	t.Cleanup(func() { f.Value.Set(old) })
+}
+
+// setNow fixes the clock of the time-dependent tools for the rest of the
+// test.
+func setNow(t *testing.T, at time.Time) {
+	t.Helper()
+	old := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = old })
+}
+
+// localTime parses a "2006-01-02 15:04" time in the local zone, as flight
+// departures are.
+func localTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	at, err := time.ParseInLocation(layoverTimeLayout, value, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return at
+}
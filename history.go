@@ -0,0 +1,48 @@
+package main
+
+import (
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+// foreignEventMarker opens the user-role content ADK substitutes for
+// another agent's event in an agent's history.
+const foreignEventMarker = "For context:"
+
+// historyWindow returns a before-model callback that drops all but the
+// last turns turns from the request sent to the model. A turn starts at a
+// user message carrying text; function responses and other agents'
+// events, which ADK also sends with the user role, do not start a turn.
+// The session keeps its full history.
+func historyWindow(turns int) func(agent.CallbackContext, *model.LLMRequest) (*model.LLMResponse, error) {
+	return func(ctx agent.CallbackContext, req *model.LLMRequest) (*model.LLMResponse, error) {
+		seen := 0
+		for i := len(req.Contents) - 1; i >= 0; i-- {
+			if !startsTurn(req.Contents[i]) {
+				continue
+			}
+			seen++
+			if seen == turns {
+				req.Contents = req.Contents[i:]
+				break
+			}
+		}
+		return nil, nil
+	}
+}
+
+func startsTurn(content *genai.Content) bool {
+	if content == nil || content.Role != genai.RoleUser {
+		return false
+	}
+	if len(content.Parts) > 0 && content.Parts[0].Text == foreignEventMarker {
+		return false
+	}
+	for _, part := range content.Parts {
+		if part.Text != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/genai"
+)
+
+func TestHistoryWindowKeepsLastTurns(t *testing.T) {
+	window := []llmagent.BeforeModelCallback{historyWindow(2)}
+	transfer := calls(transferToolName, map[string]any{"agent_name": "Booker"})
+	coordinatorModel := newMockModel(transfer, transfer, transfer)
+	bookerModel := newMockModel()
+	// Sending every turn through the coordinator puts its transfers into
+	// the Booker's history as "For context" user contents.
+	booker, err := llmagent.New(llmagent.Config{
+		Name:                     "Booker",
+		Model:                    bookerModel,
+		BeforeModelCallbacks:     window,
+		DisallowTransferToParent: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordinator, err := llmagent.New(llmagent.Config{
+		Name:                 "Coordinator",
+		Model:                coordinatorModel,
+		SubAgents:            []agent.Agent{booker},
+		BeforeModelCallbacks: window,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prompts := []string{"i want to visit london", "on 2025-11-14", "also book a hotel for me"}
+	runTurns(t, coordinator, prompts...)
+
+	last := bookerModel.requests[len(bookerModel.requests)-1]
+	var sent []string
+	for _, content := range last.Contents {
+		if content.Role != genai.RoleUser {
+			continue
+		}
+		for _, part := range content.Parts {
+			if slices.Contains(prompts, part.Text) {
+				sent = append(sent, part.Text)
+			}
+		}
+	}
+	if want := prompts[1:]; !slices.Equal(sent, want) {
+		t.Errorf("user messages sent = %q, want %q", sent, want)
+		for _, content := range last.Contents {
+			for _, part := range content.Parts {
+				t.Logf("%s: %s", content.Role, strings.TrimSpace(part.Text))
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// cityHotel is a canned hotel, located by its distance from the city
+// centre.
+type cityHotel struct {
+	Name       string
+	DistanceKm float64
+	// RateFactor scales the city's nightly rate from hotelRates.
+	RateFactor float64
+}
+
+// cityHotels is the canned hotel list by lower-case city.
+var cityHotels = map[string][]cityHotel{
+	"london": {
+		{Name: "The Strand Grand", DistanceKm: 0.5, RateFactor: 1.4},
+		{Name: "Covent Garden Inn", DistanceKm: 0.8, RateFactor: 1.1},
+		{Name: "Southbank Lodge", DistanceKm: 1.6, RateFactor: 0.9},
+		{Name: "Paddington Rooms", DistanceKm: 3.2, RateFactor: 0.75},
+	},
+	"paris": {
+		{Name: "Hôtel du Louvre Vieux", DistanceKm: 0.4, RateFactor: 1.5},
+		{Name: "Marais Maison", DistanceKm: 1.2, RateFactor: 1},
+		{Name: "Montparnasse Étoile", DistanceKm: 3.5, RateFactor: 0.8},
+	},
+	"new york": {
+		{Name: "Midtown Plaza", DistanceKm: 0.3, RateFactor: 1.5},
+		{Name: "Chelsea Loft Hotel", DistanceKm: 2.1, RateFactor: 1},
+		{Name: "Brooklyn Bridge Stay", DistanceKm: 4.8, RateFactor: 0.8},
+	},
+	"tokyo": {
+		{Name: "Ginza Tower", DistanceKm: 0.6, RateFactor: 1.4},
+		{Name: "Shinjuku Business Inn", DistanceKm: 3, RateFactor: 0.8},
+		{Name: "Asakusa Ryokan", DistanceKm: 4.2, RateFactor: 1.1},
+	},
+	"dubai": {
+		{Name: "Downtown Palace", DistanceKm: 0.5, RateFactor: 1.6},
+		{Name: "Creekside Suites", DistanceKm: 5, RateFactor: 0.85},
+	},
+}
+
+// hotelFullPercent is the share of hotel nights that are sold out.
+const hotelFullPercent = 35
+
+// hotelFull reports whether a hotel is sold out on any of the nights.
+// Availability is derived from the hotel name and date, so it is stable
+// across calls.
+func hotelFull(name string, nights []time.Time) bool {
+	for _, night := range nights {
+		h := fnv.New32a()
+		h.Write([]byte(name + "@" + night.Format(dateLayout)))
+		if h.Sum32()%100 < hotelFullPercent {
+			return true
+		}
+	}
+	return false
+}
+
+// citySoldOut reports whether every canned hotel in city is full on night.
+// Cities without a hotel list are taken to have rooms.
+func citySoldOut(city string, night time.Time) bool {
+	hotels := cityHotels[strings.ToLower(strings.TrimSpace(city))]
+	for _, h := range hotels {
+		if !hotelFull(h.Name, []time.Time{night}) {
+			return false
+		}
+	}
+	return len(hotels) > 0
+}
+
+type alternativeHotel struct {
+	Name       string  `json:"name"`
+	DistanceKm float64 `json:"distance_km"`
+	Price      float64 `json:"price"`
+}
+
+type findAlternativeHotelsArg struct {
+	Location  string `json:"location" jsonschema:"the city to stay in"`
+	CheckIn   string `json:"check_in" jsonschema:"the check-in date, YYYY-MM-DD"`
+	Nights    int    `json:"nights" jsonschema:"the number of nights; defaults to 1"`
+	FullHotel string `json:"full_hotel,omitempty" jsonschema:"the hotel that is full, to leave out of the results"`
+}
+type findAlternativeHotelsResult struct {
+	Status       string             `json:"status"`
+	Hotels       []alternativeHotel `json:"hotels,omitempty"`
+	Report       string             `json:"report,omitempty"`
+	ErrorMessage string             `json:"error_message,omitempty"`
+}
+
+func findAlternativeHotels(c tool.Context, arg findAlternativeHotelsArg) findAlternativeHotelsResult {
+	checkIn, err := time.Parse(dateLayout, strings.TrimSpace(arg.CheckIn))
+	if err != nil {
+		return findAlternativeHotelsResult{Status: "error", ErrorMessage: fmt.Sprintf("Invalid check-in date %q, expected YYYY-MM-DD.", arg.CheckIn)}
+	}
+	if arg.Nights < 0 {
+		return findAlternativeHotelsResult{Status: "error", ErrorMessage: "The number of nights must not be negative."}
+	}
+	if arg.Nights == 0 {
+		arg.Nights = 1
+	}
+	city := strings.ToLower(strings.TrimSpace(arg.Location))
+	hotels, ok := cityHotels[city]
+	if !ok {
+		return findAlternativeHotelsResult{Status: "error", ErrorMessage: fmt.Sprintf("No hotel information for %s.", arg.Location)}
+	}
+
+	nights := make([]time.Time, arg.Nights)
+	for i := range nights {
+		nights[i] = checkIn.AddDate(0, 0, i)
+	}
+	var available []alternativeHotel
+	for _, h := range hotels {
+		if strings.EqualFold(h.Name, strings.TrimSpace(arg.FullHotel)) || hotelFull(h.Name, nights) {
+			continue
+		}
+		var price float64
+		for _, night := range nights {
+			price += hotelPrice(city, night.Format(dateLayout)) * h.RateFactor
+		}
+		available = append(available, alternativeHotel{
+			Name:       h.Name,
+			DistanceKm: h.DistanceKm,
+			Price:      math.Round(price*100) / 100,
+		})
+	}
+	if len(available) == 0 {
+		return findAlternativeHotelsResult{
+			Status: "success",
+			Report: fmt.Sprintf("No nearby hotels in %s have availability for those dates.", arg.Location),
+		}
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		if available[i].DistanceKm != available[j].DistanceKm {
+			return available[i].DistanceKm < available[j].DistanceKm
+		}
+		return available[i].Price < available[j].Price
+	})
+	return findAlternativeHotelsResult{
+		Status: "success",
+		Hotels: available,
+		Report: fmt.Sprintf("%d hotels in %s have availability, nearest first.", len(available), arg.Location),
+	}
+}
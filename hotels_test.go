@@ -0,0 +1,32 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestFindAlternativeHotels(t *testing.T) {
+	c := newTestContext(t)
+	got := findAlternativeHotels(c, findAlternativeHotelsArg{Location: "London", CheckIn: "2025-11-10", Nights: 1, FullHotel: "The Strand Grand"})
+	if got.Status != "success" {
+		t.Fatalf("findAlternativeHotels: %s", got.ErrorMessage)
+	}
+	if len(got.Hotels) < 2 {
+		t.Fatalf("no alternatives found: %s", got.Report)
+	}
+	if !sort.SliceIsSorted(got.Hotels, func(i, j int) bool { return got.Hotels[i].DistanceKm < got.Hotels[j].DistanceKm }) {
+		t.Errorf("hotels not sorted nearest first: %+v", got.Hotels)
+	}
+	for _, h := range got.Hotels {
+		if h.Name == "The Strand Grand" {
+			t.Errorf("the full hotel was suggested: %+v", got.Hotels)
+		}
+		if h.Price <= 0 {
+			t.Errorf("%s has price %.2f, want a positive price", h.Name, h.Price)
+		}
+	}
+
+	if got := findAlternativeHotels(c, findAlternativeHotelsArg{Location: "Atlantis", CheckIn: "2025-11-12"}); got.Status != "error" {
+		t.Errorf("unknown city: got status %q, want error", got.Status)
+	}
+}
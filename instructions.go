@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// coordinatorFragments are the named pieces the coordinator instruction is
+// composed from.
+var coordinatorFragments = map[string]string{
+	"persona": "You are an assistant.",
+	"rules":   "Delegate booking tasks to Booker and info requests to Info.",
+	"tone":    "Be concise and friendly, and confirm the details of anything you book.",
+	"safety":  "Never invent confirmation codes, prices or travel requirements; rely on the tools for them.",
+}
+
+// composeInstruction joins the fragments named in the comma-separated
+// order, in that order. At least one fragment is required.
+func composeInstruction(order string, fragments map[string]string) (string, error) {
+	var parts []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(order, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		text, ok := fragments[name]
+		if !ok {
+			return "", fmt.Errorf("unknown instruction fragment %q", name)
+		}
+		if seen[name] {
+			return "", fmt.Errorf("repeated instruction fragment %q", name)
+		}
+		seen[name] = true
+		parts = append(parts, text)
+	}
+	if len(parts) == 0 {
+		return "", errors.New("at least one instruction fragment is required")
+	}
+	return strings.Join(parts, " "), nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestComposeInstruction(t *testing.T) {
+	fragments := map[string]string{"a": "First.", "b": "Second.", "c": "Third."}
+	got, err := composeInstruction("c, a,b", fragments)
+	if err != nil {
+		t.Fatalf("composeInstruction: %v", err)
+	}
+	if want := "Third. First. Second."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, order := range []string{"a,d", "a,a", " , "} {
+		if _, err := composeInstruction(order, fragments); err == nil {
+			t.Errorf("composeInstruction(%q) succeeded, want an error", order)
+		}
+	}
+}
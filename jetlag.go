@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// utcOffsets is a canned table of standard-time UTC offsets in hours,
+// keyed by lower-case city name.
+var utcOffsets = map[string]float64{
+	"london":      0,
+	"edinburgh":   0,
+	"reykjavik":   0,
+	"paris":       1,
+	"frankfurt":   1,
+	"krakow":      1,
+	"nice":        1,
+	"marrakesh":   1,
+	"nairobi":     3,
+	"dubai":       4,
+	"mumbai":      5.5,
+	"bali":        8,
+	"singapore":   8,
+	"tokyo":       9,
+	"sydney":      10,
+	"new york":    -5,
+	"boston":      -5,
+	"san juan":    -4,
+	"los angeles": -8,
+}
+
+type jetLagAdviceArg struct {
+	Origin      string `json:"origin" jsonschema:"the city the traveler departs from"`
+	Destination string `json:"destination" jsonschema:"the city the traveler arrives in"`
+}
+type jetLagAdviceResult struct {
+	Status          string   `json:"status"`
+	TimeDifference  float64  `json:"time_difference_hours"`
+	Recommendations []string `json:"recommendations,omitempty"`
+	Report          string   `json:"report,omitempty"`
+	ErrorMessage    string   `json:"error_message,omitempty"`
+}
+
+func jetLagAdvice(c tool.Context, arg jetLagAdviceArg) jetLagAdviceResult {
+	from, ok := utcOffsets[strings.ToLower(strings.TrimSpace(arg.Origin))]
+	if !ok {
+		return jetLagAdviceResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown timezone for %s.", arg.Origin)}
+	}
+	to, ok := utcOffsets[strings.ToLower(strings.TrimSpace(arg.Destination))]
+	if !ok {
+		return jetLagAdviceResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown timezone for %s.", arg.Destination)}
+	}
+
+	// The body clock shifts by the shorter way round the globe, so a
+	// difference of more than 12 hours is the other direction.
+	diff := math.Mod(to-from, 24)
+	switch {
+	case diff > 12:
+		diff -= 24
+	case diff <= -12:
+		diff += 24
+	}
+	hours := math.Abs(diff)
+	if hours <= 2 {
+		return jetLagAdviceResult{
+			Status:         "success",
+			TimeDifference: diff,
+			Report:         fmt.Sprintf("No significant jet lag expected between %s and %s.", arg.Origin, arg.Destination),
+		}
+	}
+
+	direction := "east"
+	shift := "go to bed and wake up an hour earlier"
+	if diff < 0 {
+		direction = "west"
+		shift = "go to bed and wake up an hour later"
+	}
+	recommendations := []string{
+		"Drink plenty of water and limit alcohol and caffeine during the flight.",
+		"Set your watch to destination time when you board.",
+		"Get daylight exposure in the morning after arrival and avoid long naps.",
+	}
+	if hours > 5 {
+		recommendations = append(recommendations,
+			fmt.Sprintf("For a few days before departure, %s each day.", shift),
+			"Plan light activities for the first day and avoid important meetings.",
+		)
+	}
+	if hours > 8 {
+		recommendations = append(recommendations,
+			"Consider a short-term melatonin schedule after checking with a doctor.",
+			fmt.Sprintf("Expect roughly %.0f days to fully adjust.", math.Ceil(hours/1.5)),
+		)
+	}
+	return jetLagAdviceResult{
+		Status:          "success",
+		TimeDifference:  diff,
+		Recommendations: recommendations,
+		Report:          fmt.Sprintf("Travelling %.1f hours %s from %s to %s.", hours, direction, arg.Origin, arg.Destination),
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJetLagAdvice(t *testing.T) {
+	c := newTestContext(t)
+	tests := []struct {
+		origin, destination string
+		wantDiff            float64
+		wantDirection       string
+		wantAdvice          int
+	}{
+		{"London", "Paris", 1, "", 0},
+		{"London", "Tokyo", 9, "east", 7},
+		{"New York", "Tokyo", -10, "west", 7},
+		{"Los Angeles", "Sydney", -6, "west", 5},
+	}
+	for _, tt := range tests {
+		got := jetLagAdvice(c, jetLagAdviceArg{Origin: tt.origin, Destination: tt.destination})
+		if got.Status != "success" {
+			t.Errorf("%s to %s: Status = %q (%s)", tt.origin, tt.destination, got.Status, got.ErrorMessage)
+			continue
+		}
+		if got.TimeDifference != tt.wantDiff {
+			t.Errorf("%s to %s: TimeDifference = %v, want %v", tt.origin, tt.destination, got.TimeDifference, tt.wantDiff)
+		}
+		if !strings.Contains(got.Report, tt.wantDirection) {
+			t.Errorf("%s to %s: Report = %q, want direction %q", tt.origin, tt.destination, got.Report, tt.wantDirection)
+		}
+		if len(got.Recommendations) != tt.wantAdvice {
+			t.Errorf("%s to %s: %d recommendations, want %d", tt.origin, tt.destination, len(got.Recommendations), tt.wantAdvice)
+		}
+	}
+}
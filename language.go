@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abadojack/whatlanggo"
+	"google.golang.org/adk/agent"
+)
+
+// detectableLanguages limits detection to languages users are likely to
+// write in; short inputs are detected far more reliably from a small set.
+var detectableLanguages = whatlanggo.Options{
+	Whitelist: map[whatlanggo.Lang]bool{
+		whatlanggo.Eng: true,
+		whatlanggo.Fra: true,
+		whatlanggo.Spa: true,
+		whatlanggo.Deu: true,
+		whatlanggo.Ita: true,
+		whatlanggo.Por: true,
+		whatlanggo.Nld: true,
+	},
+}
+
+// detectLanguage returns the name of the language text is written in,
+// falling back to English when confidence is below minConfidence.
+func detectLanguage(text string, minConfidence float64) string {
+	info := whatlanggo.DetectWithOptions(text, detectableLanguages)
+	if info.Confidence < minConfidence || info.Lang.String() == "" {
+		return whatlanggo.Eng.String()
+	}
+	return info.Lang.String()
+}
+
+// languageInstruction returns an instruction provider that tells every
+// agent to answer in the language of the turn's user input.
+func languageInstruction(minConfidence float64) func(agent.ReadonlyContext) (string, error) {
+	return func(ctx agent.ReadonlyContext) (string, error) {
+		var sb strings.Builder
+		if content := ctx.UserContent(); content != nil {
+			for _, part := range content.Parts {
+				sb.WriteString(part.Text)
+				sb.WriteString(" ")
+			}
+		}
+		return fmt.Sprintf("Always respond in %s.", detectLanguage(sb.String(), minConfidence)), nil
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestLanguageInstruction(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"Je voudrais réserver un hôtel à Paris pour la semaine prochaine, s'il vous plaît.", "Always respond in French."},
+		{"I would like to book a hotel in Paris for next week, please.", "Always respond in English."},
+	}
+	provider := languageInstruction(0.25)
+	for _, tt := range tests {
+		c := newTestContext(t)
+		c.userContent = genai.NewContentFromText(tt.input, genai.RoleUser)
+		got, err := provider(c)
+		if err != nil {
+			t.Fatalf("provider: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
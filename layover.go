@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// minConnectionMinutes is the canned minimum connection time by airport
+// code.
+var minConnectionMinutes = map[string]int{
+	"LHR": 90,
+	"LGW": 75,
+	"CDG": 90,
+	"ORY": 60,
+	"FRA": 45,
+	"AMS": 50,
+	"DXB": 75,
+	"SIN": 60,
+	"JFK": 90,
+	"EWR": 75,
+	"HND": 60,
+	"NRT": 75,
+}
+
+// defaultMinConnectionMinutes is used for airports missing from
+// minConnectionMinutes.
+const defaultMinConnectionMinutes = 60
+
+// airportTransferMinutes is the canned ground transfer time between
+// airports serving the same city, keyed by "AAA-BBB" in either order.
+var airportTransferMinutes = map[string]int{
+	"LHR-LGW": 150,
+	"CDG-ORY": 120,
+	"JFK-EWR": 150,
+	"HND-NRT": 120,
+}
+
+// tightLayoverMargin is how far above the minimum a layover must be to
+// count as comfortable.
+const tightLayoverMargin = 30 * time.Minute
+
+// layoverTimeLayout is the format of leg times.
+const layoverTimeLayout = "2006-01-02 15:04"
+
+type checkLayoverArg struct {
+	ArrivalAirport   string `json:"arrival_airport" jsonschema:"the airport code the first leg arrives at, e.g. LHR"`
+	ArrivalTime      string `json:"arrival_time" jsonschema:"when the first leg arrives, YYYY-MM-DD HH:MM local time"`
+	DepartureAirport string `json:"departure_airport" jsonschema:"the airport code the second leg departs from"`
+	DepartureTime    string `json:"departure_time" jsonschema:"when the second leg departs, YYYY-MM-DD HH:MM local time"`
+}
+type checkLayoverResult struct {
+	Status         string `json:"status"`
+	Feasibility    string `json:"feasibility,omitempty"`
+	LayoverMinutes int    `json:"layover_minutes"`
+	MinimumMinutes int    `json:"minimum_minutes"`
+	Warning        string `json:"warning,omitempty"`
+	Report         string `json:"report,omitempty"`
+	ErrorMessage   string `json:"error_message,omitempty"`
+}
+
+func checkLayover(c tool.Context, arg checkLayoverArg) checkLayoverResult {
+	arrival, err := time.Parse(layoverTimeLayout, strings.TrimSpace(arg.ArrivalTime))
+	if err != nil {
+		return checkLayoverResult{Status: "error", ErrorMessage: fmt.Sprintf("Invalid arrival time %q, expected YYYY-MM-DD HH:MM.", arg.ArrivalTime)}
+	}
+	departure, err := time.Parse(layoverTimeLayout, strings.TrimSpace(arg.DepartureTime))
+	if err != nil {
+		return checkLayoverResult{Status: "error", ErrorMessage: fmt.Sprintf("Invalid departure time %q, expected YYYY-MM-DD HH:MM.", arg.DepartureTime)}
+	}
+	from := strings.ToUpper(strings.TrimSpace(arg.ArrivalAirport))
+	to := strings.ToUpper(strings.TrimSpace(arg.DepartureAirport))
+
+	minutes, ok := minConnectionMinutes[to]
+	if !ok {
+		minutes = defaultMinConnectionMinutes
+	}
+	if from != to {
+		transfer, ok := airportTransferMinutes[from+"-"+to]
+		if !ok {
+			transfer, ok = airportTransferMinutes[to+"-"+from]
+		}
+		if !ok {
+			return checkLayoverResult{
+				Status:      "success",
+				Feasibility: "impossible",
+				Report:      fmt.Sprintf("There is no known ground transfer from %s to %s; this connection is not possible.", from, to),
+			}
+		}
+		minutes += transfer
+	}
+	minimum := time.Duration(minutes) * time.Minute
+	layover := departure.Sub(arrival)
+
+	result := checkLayoverResult{
+		Status:         "success",
+		LayoverMinutes: int(layover.Minutes()),
+		MinimumMinutes: minutes,
+	}
+	switch {
+	case layover < minimum:
+		result.Feasibility = "impossible"
+		result.Report = fmt.Sprintf("A %d minute layover is below the %d minute minimum connection time; this connection is not possible.", result.LayoverMinutes, minutes)
+	case layover < minimum+tightLayoverMargin:
+		result.Feasibility = "tight"
+		result.Warning = fmt.Sprintf("Only %d minutes against a %d minute minimum; any delay risks a missed connection.", result.LayoverMinutes, minutes)
+		result.Report = "The connection is possible but tight."
+	default:
+		result.Feasibility = "comfortable"
+		result.Report = fmt.Sprintf("A %d minute layover comfortably exceeds the %d minute minimum.", result.LayoverMinutes, minutes)
+	}
+	return result
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestCheckLayover(t *testing.T) {
+	c := newTestContext(t)
+	tests := []struct {
+		name         string
+		from, to     string
+		departure    string
+		wantFeasible string
+		wantMinimum  int
+	}{
+		{"comfortable", "LHR", "LHR", "2025-11-14 13:00", "comfortable", 90},
+		{"tight", "LHR", "LHR", "2025-11-14 11:45", "tight", 90},
+		{"impossible", "LHR", "LHR", "2025-11-14 11:00", "impossible", 90},
+		{"airport change", "LHR", "LGW", "2025-11-14 13:00", "impossible", 225},
+		{"unknown transfer", "LHR", "CDG", "2025-11-14 20:00", "impossible", 0},
+	}
+	for _, tt := range tests {
+		got := checkLayover(c, checkLayoverArg{
+			ArrivalAirport:   tt.from,
+			ArrivalTime:      "2025-11-14 10:00",
+			DepartureAirport: tt.to,
+			DepartureTime:    tt.departure,
+		})
+		if got.Status != "success" || got.Feasibility != tt.wantFeasible {
+			t.Errorf("%s: got %+v, want %s", tt.name, got, tt.wantFeasible)
+		}
+		if got.MinimumMinutes != tt.wantMinimum {
+			t.Errorf("%s: MinimumMinutes = %d, want %d", tt.name, got.MinimumMinutes, tt.wantMinimum)
+		}
+	}
+	if got := checkLayover(c, checkLayoverArg{ArrivalTime: "tomorrow"}); got.Status != "error" {
+		t.Errorf("invalid time: Status = %q, want error", got.Status)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// Spending log categories.
+const (
+	chargeBooking = "booking"
+	chargeFee     = "fee"
+	chargeUpgrade = "upgrade"
+	chargeOffset  = "offset"
+)
+
+// ledgerEntry is one charge made during a session. Upgrades paid with
+// points carry the points and a zero amount.
+type ledgerEntry struct {
+	Time         time.Time `json:"time"`
+	Category     string    `json:"category"`
+	Description  string    `json:"description"`
+	Confirmation string    `json:"confirmation,omitempty"`
+	Amount       float64   `json:"amount"`
+	Points       int       `json:"points,omitempty"`
+}
+
+// ledgerStore keeps the charges of each session in the order they were
+// made.
+type ledgerStore struct {
+	mu        sync.Mutex
+	bySession map[string][]ledgerEntry
+}
+
+var spending = &ledgerStore{bySession: make(map[string][]ledgerEntry)}
+
+// record appends a charge to the session's log, stamped with the current
+// time.
+func (s *ledgerStore) record(sessionID string, e ledgerEntry) {
+	e.Time = now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bySession[sessionID] = append(s.bySession[sessionID], e)
+}
+
+func (s *ledgerStore) forSession(sessionID string) []ledgerEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ledgerEntry(nil), s.bySession[sessionID]...)
+}
+
+// recordBooking logs the price of a newly made booking.
+func recordBooking(sessionID, confirmation string) {
+	b, ok := bookings.get(sessionID, confirmation)
+	if !ok {
+		return
+	}
+	description := fmt.Sprintf("Hotel in %s on %s", b.Location, b.Date)
+	if b.Kind == kindFlight {
+		description = fmt.Sprintf("Flight %s to %s on %s (%s)", b.Origin, b.Destination, b.Date, b.FareClass)
+	}
+	spending.record(sessionID, ledgerEntry{
+		Category:     chargeBooking,
+		Description:  description,
+		Confirmation: b.Confirmation,
+		Amount:       b.Price,
+	})
+}
+
+// ledgerTotal sums the amounts of entries.
+func ledgerTotal(entries []ledgerEntry) float64 {
+	var total float64
+	for _, e := range entries {
+		total += e.Amount
+	}
+	return math.Round(total*100) / 100
+}
+
+// renderLedger formats entries one per line, oldest first.
+func renderLedger(entries []ledgerEntry) string {
+	var sb strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "%s  %-8s  %-48s  %10.2f", e.Time.Format(layoverTimeLayout), e.Category, e.Description, e.Amount)
+		if e.Points > 0 {
+			fmt.Fprintf(&sb, "  (%d points)", e.Points)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+type getSpendingLogArg struct{}
+type getSpendingLogResult struct {
+	Status  string        `json:"status"`
+	Entries []ledgerEntry `json:"entries,omitempty"`
+	Total   float64       `json:"total"`
+	Report  string        `json:"report,omitempty"`
+}
+
+func getSpendingLog(c tool.Context, arg getSpendingLogArg) getSpendingLogResult {
+	entries := spending.forSession(c.SessionID())
+	if len(entries) == 0 {
+		return getSpendingLogResult{Status: "success", Report: "Nothing has been charged in this session."}
+	}
+	total := ledgerTotal(entries)
+	return getSpendingLogResult{
+		Status:  "success",
+		Entries: entries,
+		Total:   total,
+		Report:  fmt.Sprintf("Spending log\n%sTotal charged: %.2f\n", renderLedger(entries), total),
+	}
+}
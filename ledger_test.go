@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestSpendingLogRecordsBookingAndUpgrade(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Dubai", Date: "2025-11-14"})
+	code := confirmationOf(t, c)
+	b, _ := bookings.get(c.SessionID(), code)
+	estimateBaggageFees(c, estimateBaggageFeesArg{Confirmation: code, Bags: 1, Apply: true})
+	if got := upgradeWithPoints(c, upgradeWithPointsArg{Confirmation: code}); got.Status != "success" {
+		t.Fatalf("upgradeWithPoints: %s", got.ErrorMessage)
+	}
+
+	got := getSpendingLog(c, getSpendingLogArg{})
+	if len(got.Entries) != 3 {
+		t.Fatalf("got %d entries, want the booking, the bag fee and the upgrade: %+v", len(got.Entries), got.Entries)
+	}
+	booking, fee, upgrade := got.Entries[0], got.Entries[1], got.Entries[2]
+	if booking.Category != chargeBooking || booking.Amount != b.Price {
+		t.Errorf("first entry = %+v, want the booking at %.2f", booking, b.Price)
+	}
+	if upgrade.Category != chargeUpgrade || upgrade.Amount != 0 || upgrade.Points != upgradePaths["economy"].cost {
+		t.Errorf("third entry = %+v, want a points-only upgrade", upgrade)
+	}
+	if fee.Category != chargeFee || fee.Amount != 35 {
+		t.Errorf("second entry = %+v, want the 35 baggage fee", fee)
+	}
+	if want := ledgerTotal([]ledgerEntry{booking, fee}); got.Total != want {
+		t.Errorf("total = %.2f, want %.2f", got.Total, want)
+	}
+}
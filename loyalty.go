@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/adk/tool"
+)
+
+// startingPoints is the canned frequent-flyer balance of a new user.
+const startingPoints = 40000
+
+// upgradePaths maps a fare class to the class it upgrades to and the
+// points the upgrade costs.
+var upgradePaths = map[string]struct {
+	next string
+	cost int
+}{
+	"economy":         {next: "premium economy", cost: 15000},
+	"premium economy": {next: "business", cost: 30000},
+}
+
+// loyaltyAccounts keeps frequent-flyer balances by user ID.
+type loyaltyAccounts struct {
+	mu       sync.Mutex
+	balances map[string]int
+}
+
+var loyalty = &loyaltyAccounts{balances: make(map[string]int)}
+
+func (a *loyaltyAccounts) balance(userID string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.balanceLocked(userID)
+}
+
+func (a *loyaltyAccounts) balanceLocked(userID string) int {
+	b, ok := a.balances[userID]
+	if !ok {
+		b = startingPoints
+		a.balances[userID] = b
+	}
+	return b
+}
+
+// redeem deducts points from the user's balance if it is sufficient and
+// returns the remaining balance.
+func (a *loyaltyAccounts) redeem(userID string, points int) (int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	b := a.balanceLocked(userID)
+	if b < points {
+		return b, false
+	}
+	a.balances[userID] = b - points
+	return b - points, true
+}
+
+type upgradeWithPointsArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type upgradeWithPointsResult struct {
+	Status       string `json:"status"`
+	FareClass    string `json:"fare_class,omitempty"`
+	PointsUsed   int    `json:"points_used,omitempty"`
+	Balance      int    `json:"points_balance"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func upgradeWithPoints(c tool.Context, arg upgradeWithPointsArg) upgradeWithPointsResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return upgradeWithPointsResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	path, ok := upgradePaths[b.FareClass]
+	if !ok {
+		return upgradeWithPointsResult{
+			Status:       "error",
+			Balance:      loyalty.balance(c.UserID()),
+			ErrorMessage: fmt.Sprintf("%s is already in %s and cannot be upgraded further.", b.Confirmation, b.FareClass),
+		}
+	}
+	balance, ok := loyalty.redeem(c.UserID(), path.cost)
+	if !ok {
+		return upgradeWithPointsResult{
+			Status:       "error",
+			Balance:      balance,
+			ErrorMessage: fmt.Sprintf("Upgrading to %s costs %d points but the balance is only %d.", path.next, path.cost, balance),
+		}
+	}
+	bookings.update(c.SessionID(), b.Confirmation, func(b *booking) {
+		b.FareClass = path.next
+		// A seat from the old cabin does not carry over.
+		if b.Seat != "" {
+			b.Seat = ""
+			b.Seat = assignSeat(*b)
+		}
+	})
+	spending.record(c.SessionID(), ledgerEntry{
+		Category:     chargeUpgrade,
+		Description:  fmt.Sprintf("Upgrade to %s", path.next),
+		Confirmation: b.Confirmation,
+		Points:       path.cost,
+	})
+	return upgradeWithPointsResult{
+		Status:     "success",
+		FareClass:  path.next,
+		PointsUsed: path.cost,
+		Balance:    balance,
+		Report:     fmt.Sprintf("Upgraded %s to %s for %d points. %d points remain.", b.Confirmation, path.next, path.cost, balance),
+	}
+}
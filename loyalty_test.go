@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestUpgradeWithPoints(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Dubai", Date: "2025-11-14"})
+	code := confirmationOf(t, c)
+
+	// The starting balance covers economy to premium economy.
+	got := upgradeWithPoints(c, upgradeWithPointsArg{Confirmation: code})
+	if got.Status != "success" || got.FareClass != "premium economy" {
+		t.Fatalf("first upgrade: got %+v, want premium economy", got)
+	}
+	if want := startingPoints - upgradePaths["economy"].cost; got.Balance != want {
+		t.Errorf("balance after first upgrade = %d, want %d", got.Balance, want)
+	}
+
+	// The remaining balance is short of the business upgrade.
+	got = upgradeWithPoints(c, upgradeWithPointsArg{Confirmation: code})
+	if got.Status != "error" {
+		t.Fatalf("second upgrade: got %+v, want an insufficient balance error", got)
+	}
+	if b, _ := bookings.get(c.SessionID(), code); b.FareClass != "premium economy" {
+		t.Errorf("fare class after refused upgrade = %q, want premium economy", b.FareClass)
+	}
+}
@@ -2,14 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
-	"google.golang.org/adk/model/gemini"
 	"google.golang.org/adk/runner"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
@@ -18,24 +21,48 @@ import (
 )
 
 // --- 1. STATIC TOOL FUNCTIONS ---
-// As requested, these are static functions that just return a
-// confirmation string. This is where you'd call a real API.
+// As requested, these are static functions that just record the booking
+// and return a confirmation string. This is where you'd call a real API.
 type bookHotelArg struct {
 	Location string `json:"location" jsonschema:"the location of the hotel"`
 	Date     string `json:"date" jsonschema:"the date of the booking"`
 }
 type bookHotelResult struct {
-	Status       string `json:"status"`
-	Report       string `json:"report,omitempty"`
-	ErrorMessage string `json:"error_message,omitempty"`
+	Status       string   `json:"status"`
+	Candidates   []string `json:"candidates,omitempty"`
+	Warning      string   `json:"warning,omitempty"`
+	Report       string   `json:"report,omitempty"`
+	ErrorMessage string   `json:"error_message,omitempty"`
 }
 
 func bookHotel(c tool.Context, arg bookHotelArg) bookHotelResult {
-	confirmation := "CONF_HOTEL_98765"
+	location, candidates := resolveCity(arg.Location)
+	if candidates != nil {
+		return bookHotelResult{
+			Status:     "needs_disambiguation",
+			Candidates: candidates,
+			Report:     disambiguationReport(arg.Location, candidates),
+		}
+	}
+	arg.Location = location
+	price := hotelPrice(arg.Location, arg.Date)
+	warning, refuse := checkBudget(c.SessionID(), price)
+	if refuse {
+		return bookHotelResult{Status: "error", ErrorMessage: warning + " The hotel was not booked."}
+	}
+	confirmation := bookings.add(booking{
+		Kind:      kindHotel,
+		SessionID: c.SessionID(),
+		Location:  arg.Location,
+		Date:      arg.Date,
+		Price:     price,
+	})
+	recordBooking(c.SessionID(), confirmation)
 	fmt.Printf("%v", arg)
 	return bookHotelResult{
 		Status:       "success",
-		Report:       fmt.Sprintf("Hotel booked in %s on %s. Confirmation: %s", arg.Location, arg.Date, confirmation),
+		Warning:      warning,
+		Report:       fmt.Sprintf("Hotel booked in %s on %s for %.2f. Confirmation: %s", arg.Location, arg.Date, price, confirmation),
 		ErrorMessage: "",
 	}
 }
@@ -44,28 +71,112 @@ type bookFlightArg struct {
 	Origin      string `json:"origin" jsonschema:"the origin of the flight"`
 	Destination string `json:"destination" jsonschema:"the destination of the flight"`
 	Date        string `json:"date" jsonschema:"the date of the booking"`
+	FareClass   string `json:"fare_class,omitempty" jsonschema:"the fare class: economy, premium economy or business; defaults to economy"`
 }
 type bookFlightResult struct {
-	Status       string `json:"status"`
-	Report       string `json:"report,omitempty"`
-	ErrorMessage string `json:"error_message,omitempty"`
+	Status       string   `json:"status"`
+	Candidates   []string `json:"candidates,omitempty"`
+	Warning      string   `json:"warning,omitempty"`
+	Report       string   `json:"report,omitempty"`
+	ErrorMessage string   `json:"error_message,omitempty"`
 }
 
 func bookFlight(c tool.Context, arg bookFlightArg) bookFlightResult {
-	confirmation := "CONF_FLIGHT_12345"
+	fareClass := strings.ToLower(strings.TrimSpace(arg.FareClass))
+	if fareClass == "" {
+		fareClass = "economy"
+	}
+	if _, ok := fareClassMultipliers[fareClass]; !ok {
+		return bookFlightResult{
+			Status:       "error",
+			ErrorMessage: fmt.Sprintf("Unknown fare class %q. Use economy, premium economy or business.", arg.FareClass),
+		}
+	}
+	for _, city := range []*string{&arg.Origin, &arg.Destination} {
+		location, candidates := resolveCity(*city)
+		if candidates != nil {
+			return bookFlightResult{
+				Status:     "needs_disambiguation",
+				Candidates: candidates,
+				Report:     disambiguationReport(*city, candidates),
+			}
+		}
+		*city = location
+	}
+	price := flightPrice(arg.Origin, arg.Destination, fareClass, arg.Date)
+	warning, refuse := checkBudget(c.SessionID(), price)
+	if refuse {
+		return bookFlightResult{Status: "error", ErrorMessage: warning + " The flight was not booked."}
+	}
+	confirmation := bookings.add(booking{
+		Kind:        kindFlight,
+		SessionID:   c.SessionID(),
+		Origin:      arg.Origin,
+		Destination: arg.Destination,
+		FareClass:   fareClass,
+		Date:        arg.Date,
+		Price:       price,
+	})
+	recordBooking(c.SessionID(), confirmation)
 	fmt.Printf("%v", arg)
 	return bookFlightResult{
 		Status:       "success",
-		Report:       fmt.Sprintf("Flight booked from %s to %s on %s. Confirmation: %s", arg.Origin, arg.Destination, arg.Date, confirmation),
+		Warning:      warning,
+		Report:       fmt.Sprintf("Flight booked from %s to %s on %s for %.2f. Confirmation: %s", arg.Origin, arg.Destination, arg.Date, price, confirmation),
 		ErrorMessage: "",
 	}
 }
 
 // ---------------------------------
 
+var (
+	modelName            = flag.String("model", "gemini-2.5-flash", "Gemini model used by the agents")
+	checkConfig          = flag.Bool("check-config", false, "validate the configuration and exit without running the agent")
+	breakerFailures      = flag.Int("breaker-failures", 5, "consecutive model failures that open the circuit breaker; 0 disables it")
+	breakerCooldown      = flag.Duration("breaker-cooldown", 30*time.Second, "how long the open circuit breaker rejects model calls before trying again")
+	outputFormat         = flag.String("output", outputMarkdown, "how responses are rendered: markdown, or plain to strip markdown for terminals")
+	demoLatency          = flag.Duration("demo-latency", 0, "demo only: artificial delay added to every tool call, with a tenth of it between printed words")
+	maxSessions          = flag.Int("max-sessions", 0, "maximum number of sessions kept in memory, evicting the least recently used; 0 means unlimited")
+	dedupToolCalls       = flag.Bool("dedup-tool-calls", false, "return the cached result when a tool is called twice with identical arguments in one turn")
+	subAgentOrder        = flag.String("subagent-order", "Booker,Info", "comma-separated order in which the coordinator considers its sub-agents")
+	autoLanguage         = flag.Bool("auto-language", false, "detect the language of each user input and respond in it")
+	languageConfidence   = flag.Float64("language-confidence", 0.25, "minimum detection confidence for -auto-language before falling back to English")
+	historyTurns         = flag.Int("history-turns", 0, "number of most recent turns sent to the model; 0 sends the whole history")
+	recoverUnknownTools  = flag.Bool("recover-unknown-tools", true, "answer calls to unregistered tools with an error listing the available tools instead of failing the turn")
+	bookerModelName      = flag.String("booker-model", "", "Gemini model used by the Booker agent; defaults to -model")
+	infoModelName        = flag.String("info-model", "", "Gemini model used by the Info agent; defaults to -model")
+	instructionFragments = flag.String("instruction-fragments", "persona,rules,tone,safety", "comma-separated instruction fragments the coordinator instruction is composed from, in order")
+	surfaceToolErrors    = flag.Bool("surface-tool-errors", false, "print tool error results alongside the agent response so failures are not hidden")
+	retryTools           = flag.String("retry-tools", "", "comma-separated tools whose transient failures are retried with backoff")
+	retryAttempts        = flag.Int("retry-attempts", 3, "total attempts for a retryable tool call")
+	retryBackoff         = flag.Duration("retry-backoff", 200*time.Millisecond, "wait before the first retry of a tool call, doubled after each retry")
+	rawEvents            = flag.Bool("raw", false, "debugging: print every raw event of a turn as JSON alongside the agent response")
+	streamResponses      = flag.Bool("stream", false, "stream responses as the model generates them")
+	demoScript           = flag.String("demo-script", "", "run the prompts of this script instead of the built-in demo, checking its >assert lines against the responses")
+	degradedMessage      = flag.String("degraded-message", "The assistant is temporarily unavailable. Please try again in a few minutes.", "message shown instead of an error while the model circuit breaker is open")
+	ruleFallback         = flag.Bool("rule-fallback", false, "while the model is unavailable, answer requests to list or cancel bookings directly from the booking store")
+	errorVerbosity       = flag.String("error-verbosity", errorsFriendly, "how much of an error is shown: friendly, detailed, or debug for the full chain")
+	warmup               = flag.Bool("warmup", false, "make a tiny model call at startup so the first turn does not pay for connection setup")
+	stopSequences        = listFlag("stop", "stop sequence that ends model output when generated; repeat for several")
+	listSessions         = flag.Bool("list-sessions", false, "print the user's sessions and their titles after the run")
+	disambiguationPrompt = flag.String("disambiguation-prompt", "Ask the user which one they mean, then book again with the qualified name.", "guidance returned to the model when a booking names an ambiguous city")
+	hardBudget           = flag.Bool("hard-budget", false, "refuse bookings that would exceed the session budget instead of only warning")
+)
+
 func main() {
+	flag.Parse()
+	if problems := validateFlags(); *checkConfig || len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintf(os.Stderr, "config: %v\n", p)
+		}
+		if len(problems) > 0 {
+			os.Exit(1)
+		}
+		fmt.Println("config OK")
+		return
+	}
 	if err := runAgent(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %s\n", describeStartupError(err, *errorVerbosity))
 		os.Exit(1)
 	}
 }
@@ -75,17 +186,24 @@ func runAgent() error {
 		return fmt.Errorf("loading .env file: %w", err)
 	}
 
+	var script []scriptStep
+	if *demoScript != "" {
+		var err error
+		if script, err = loadDemoScript(*demoScript); err != nil {
+			return err
+		}
+	}
+
 	ctx := context.Background()
 	key := os.Getenv("API_KEY")
 	if key == "" {
 		return fmt.Errorf("API_KEY environment variable is not set")
 	}
 
-	model, err := gemini.NewModel(ctx, "gemini-2.5-flash", &genai.ClientConfig{
-		APIKey: key,
-	})
+	models := newModelCache(ctx, key)
+	agentModels, err := configuredAgentModels(models)
 	if err != nil {
-		return fmt.Errorf("creating Gemini model: %w", err)
+		return err
 	}
 
 	// --- 2. CREATE TOOLS FROM YOUR FUNCTIONS ---
@@ -111,40 +229,581 @@ func runAgent() error {
 		return fmt.Errorf("creating flight tool: %w", err)
 	}
 
-	// -------------------------------------------
+	connectionsTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "suggestConnections",
+			Description: "Use this function to suggest one-stop routes through hub cities when there is no direct flight. Requires origin and destination.",
+		},
+		suggestConnections,
+	)
+	if err != nil {
+		return fmt.Errorf("creating connections tool: %w", err)
+	}
 
-	// --- 3. ADD TOOLS TO YOUR AGENT ---
-	bookingAgent, err := llmagent.New(llmagent.Config{
-		Name:        "Booker",
-		Description: "Handles flight and hotel bookings. Use your tools for any booking request.",
-		Model:       model,
-		Tools:       []tool.Tool{hotelTool, flightTool},
-	})
+	destinationInfoTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getDestinationInfo",
+			Description: "Use this function to look up a country's currency, tipping norms, and emergency numbers. Requires country.",
+		},
+		getDestinationInfo,
+	)
 	if err != nil {
-		return fmt.Errorf("creating booking agent: %w", err)
+		return fmt.Errorf("creating destination info tool: %w", err)
 	}
 
-	infoAgent, err := llmagent.New(llmagent.Config{
-		Name:        "Info",
-		Description: "Provides general information and answers questions.",
-		Model:       model,
-	})
+	jetLagTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "jetLagAdvice",
+			Description: "Use this function to get jet lag recovery recommendations. Requires origin and destination cities.",
+		},
+		jetLagAdvice,
+	)
+	if err != nil {
+		return fmt.Errorf("creating jet lag tool: %w", err)
+	}
+
+	setMetadataTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "setSessionMetadata",
+			Description: "Use this function to remember a fact about the session, like trip purpose or company. Requires key and value.",
+		},
+		setSessionMetadata,
+	)
+	if err != nil {
+		return fmt.Errorf("creating set metadata tool: %w", err)
+	}
+
+	getMetadataTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getSessionMetadata",
+			Description: "Use this function to recall the metadata stored for this session.",
+		},
+		getSessionMetadata,
+	)
+	if err != nil {
+		return fmt.Errorf("creating get metadata tool: %w", err)
+	}
+
+	baggageTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "estimateBaggageFees",
+			Description: "Use this function to estimate checked baggage fees for a booked flight. Requires the flight confirmation code and number of bags; set apply to add the fee to the booking.",
+		},
+		estimateBaggageFees,
+	)
+	if err != nil {
+		return fmt.Errorf("creating baggage tool: %w", err)
+	}
+
+	expenseReportTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "generateExpenseReport",
+			Description: "Use this function to compile the session's bookings into an expense report with totals per category.",
+		},
+		generateExpenseReport,
+	)
+	if err != nil {
+		return fmt.Errorf("creating expense report tool: %w", err)
+	}
+
+	airportArrivalTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "airportArrivalAdvice",
+			Description: "Use this function to recommend when to leave for the airport. Requires the flight confirmation code and the departure point.",
+		},
+		airportArrivalAdvice,
+	)
+	if err != nil {
+		return fmt.Errorf("creating airport arrival tool: %w", err)
+	}
+
+	createTripTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "createTripReference",
+			Description: "Use this function to group the session's current bookings under one trip reference so they can be managed together.",
+		},
+		createTripReference,
+	)
+	if err != nil {
+		return fmt.Errorf("creating trip reference tool: %w", err)
+	}
+
+	cancelTripTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "cancelTripReference",
+			Description: "Use this function to cancel every booking grouped under a trip reference; flights that have already departed are kept. Requires the trip reference.",
+		},
+		cancelTripReference,
+	)
+	if err != nil {
+		return fmt.Errorf("creating cancel trip tool: %w", err)
+	}
+
+	upgradeTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "upgradeWithPoints",
+			Description: "Use this function to upgrade a booked flight to the next fare class using frequent-flyer points. Requires the flight confirmation code.",
+		},
+		upgradeWithPoints,
+	)
 	if err != nil {
-		return fmt.Errorf("creating info agent: %w", err)
+		return fmt.Errorf("creating upgrade tool: %w", err)
 	}
 
-	coordinator, err := llmagent.New(llmagent.Config{
-		Name:        "Coordinator",
-		Model:       model,
-		Instruction: "You are an assistant. Delegate booking tasks to Booker and info requests to Info.",
-		Description: "Main coordinator.",
-		SubAgents:   []agent.Agent{bookingAgent, infoAgent},
+	travelTimeTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "totalTravelTime",
+			Description: "Use this function to estimate the total travel time across the session's flights, including layovers.",
+		},
+		totalTravelTime,
+	)
+	if err != nil {
+		return fmt.Errorf("creating travel time tool: %w", err)
+	}
+
+	compareDatesTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "compareTripCostByDate",
+			Description: "Use this function to compare the estimated cost of a round trip on two sets of dates. Requires origin, destination and both date sets.",
+		},
+		compareTripCostByDate,
+	)
+	if err != nil {
+		return fmt.Errorf("creating compare dates tool: %w", err)
+	}
+
+	checkInTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "checkInFlight",
+			Description: "Use this function to check in for a booked flight once check-in opens 24 hours before departure. Requires the flight confirmation code.",
+		},
+		checkInFlight,
+	)
+	if err != nil {
+		return fmt.Errorf("creating check-in tool: %w", err)
+	}
+
+	offPeakTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "recommendOffPeakDates",
+			Description: "Use this function to recommend the cheapest, least crowded dates to visit a destination in a flexible month. Requires destination and month (YYYY-MM).",
+		},
+		recommendOffPeakDates,
+	)
+	if err != nil {
+		return fmt.Errorf("creating off-peak dates tool: %w", err)
+	}
+
+	splitPaymentTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "splitPayment",
+			Description: "Use this function to split a booking's payment between two payment methods. The amounts must add up to the booking total.",
+		},
+		splitPayment,
+	)
+	if err != nil {
+		return fmt.Errorf("creating split payment tool: %w", err)
+	}
+
+	seatMapTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getSeatMap",
+			Description: "Use this function to show the seat map and free seats of a booked flight's cabin. Requires the flight confirmation code.",
+		},
+		getSeatMap,
+	)
+	if err != nil {
+		return fmt.Errorf("creating seat map tool: %w", err)
+	}
+
+	boardingRefTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getBoardingReference",
+			Description: "Use this function to get a compact boarding reference for a checked-in flight, suitable for a QR code. Requires the flight confirmation code.",
+		},
+		getBoardingReference,
+	)
+	if err != nil {
+		return fmt.Errorf("creating boarding reference tool: %w", err)
+	}
+
+	entryRequirementsTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getEntryRequirements",
+			Description: "Use this function to look up required vaccinations and health advisories for a destination country. Requires country.",
+		},
+		getEntryRequirements,
+	)
+	if err != nil {
+		return fmt.Errorf("creating entry requirements tool: %w", err)
+	}
+
+	layoverTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "checkLayover",
+			Description: "Use this function to check whether a layover between two flight legs is long enough. Requires the arrival and departure airport codes and times.",
+		},
+		checkLayover,
+	)
+	if err != nil {
+		return fmt.Errorf("creating layover tool: %w", err)
+	}
+
+	suggestUpgradesTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "suggestUpgrades",
+			Description: "Use this function to suggest cabin and hotel room upgrades for the session's bookings that fit within the user's remaining budget, best value first.",
+		},
+		suggestUpgrades,
+	)
+	if err != nil {
+		return fmt.Errorf("creating suggest upgrades tool: %w", err)
+	}
+
+	alternativeHotelsTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "findAlternativeHotels",
+			Description: "Use this function to find nearby hotels with availability when the user's chosen hotel is full. Requires the city and check-in date; the number of nights is optional.",
+		},
+		findAlternativeHotels,
+	)
+	if err != nil {
+		return fmt.Errorf("creating alternative hotels tool: %w", err)
+	}
+
+	bookingOrderTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "optimizeBookingOrder",
+			Description: "Use this function to advise the order and timing in which to book a trip's flights and hotel for the lowest total. Requires origin, destination, departure and return dates.",
+		},
+		optimizeBookingOrder,
+	)
+	if err != nil {
+		return fmt.Errorf("creating booking order tool: %w", err)
+	}
+
+	setVariableTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "setSessionVariable",
+			Description: "Use this function to record a session variable, such as destination_confirmed=true, that you will see in your instructions on every later turn.",
+		},
+		setSessionVariable,
+	)
+	if err != nil {
+		return fmt.Errorf("creating set variable tool: %w", err)
+	}
+
+	gateInfoTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getGateInfo",
+			Description: "Use this function to get the terminal, gate and boarding time of a booked flight. Requires the flight confirmation code; call again for updated gate information.",
+		},
+		getGateInfo,
+	)
+	if err != nil {
+		return fmt.Errorf("creating gate info tool: %w", err)
+	}
+
+	checklistTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "departureChecklist",
+			Description: "Use this function to produce a pre-departure checklist for the session's upcoming flights, covering check-in, documents, health requirements and when to leave for the airport.",
+		},
+		departureChecklist,
+	)
+	if err != nil {
+		return fmt.Errorf("creating departure checklist tool: %w", err)
+	}
+
+	tripBaggageTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "tripBaggageSummary",
+			Description: "Use this function to summarise the luggage allowance that holds across all flights in the session, governed by the most restrictive leg.",
+		},
+		tripBaggageSummary,
+	)
+	if err != nil {
+		return fmt.Errorf("creating trip baggage tool: %w", err)
+	}
+
+	petPolicyTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getPetPolicy",
+			Description: "Use this function to look up an airline's policy and fees for pets in the cabin and in cargo. Requires an airline or a flight confirmation code.",
+		},
+		getPetPolicy,
+	)
+	if err != nil {
+		return fmt.Errorf("creating pet policy tool: %w", err)
+	}
+
+	perDiemTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "estimatePerDiem",
+			Description: "Use this function to estimate meal and incidental costs for the session's hotel nights using per-city per diem rates, optionally adding them to the expense report.",
+		},
+		estimatePerDiem,
+	)
+	if err != nil {
+		return fmt.Errorf("creating per diem tool: %w", err)
+	}
+
+	baggageClaimTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getBaggageClaimInfo",
+			Description: "Use this function to find the baggage carousel, claim area and lost baggage contacts at a flight's arrival airport. Requires the flight confirmation code.",
+		},
+		getBaggageClaimInfo,
+	)
+	if err != nil {
+		return fmt.Errorf("creating baggage claim tool: %w", err)
+	}
+
+	suggestBundleTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "suggestBundle",
+			Description: "Use this function to price a discounted flight and hotel package for a round trip, compared with booking separately. Requires origin, destination, departure and return dates.",
+		},
+		suggestBundle,
+	)
+	if err != nil {
+		return fmt.Errorf("creating suggest bundle tool: %w", err)
+	}
+
+	bookBundleTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "bookBundle",
+			Description: "Use this function to book a flight and hotel package at the bundle discount, once the user has accepted a suggested bundle.",
+		},
+		bookBundle,
+	)
+	if err != nil {
+		return fmt.Errorf("creating book bundle tool: %w", err)
+	}
+
+	compensationTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "checkCompensationEligibility",
+			Description: "Use this function to check whether a delayed or cancelled flight may be eligible for compensation, such as under EU261, and how to claim. Requires the flight confirmation code.",
+		},
+		checkCompensationEligibility,
+	)
+	if err != nil {
+		return fmt.Errorf("creating compensation tool: %w", err)
+	}
+
+	doorToDoorTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "doorToDoorTime",
+			Description: "Use this function to estimate the total door-to-door journey time for a flight, including getting to the airport, connections and the transfer to the destination address.",
+		},
+		doorToDoorTime,
+	)
+	if err != nil {
+		return fmt.Errorf("creating door to door tool: %w", err)
+	}
+
+	tripEmailTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "composeTripEmail",
+			Description: "Use this function to write a ready-to-send email summarising the session's itinerary, confirmation codes and total cost, in plain text or HTML.",
+		},
+		composeTripEmail,
+	)
+	if err != nil {
+		return fmt.Errorf("creating trip email tool: %w", err)
+	}
+
+	comfortTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getComfortDetails",
+			Description: "Use this function to get the seat pitch, width, recline and amenities of the cabin a flight is booked in. Requires the flight confirmation code.",
+		},
+		getComfortDetails,
+	)
+	if err != nil {
+		return fmt.Errorf("creating comfort details tool: %w", err)
+	}
+
+	restrictionsTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "checkTravelRestrictions",
+			Description: "Use this function before booking to check for entry bans, restrictions and pre-travel requirements between two countries on a date.",
+		},
+		checkTravelRestrictions,
+	)
+	if err != nil {
+		return fmt.Errorf("creating travel restrictions tool: %w", err)
+	}
+
+	paymentCardTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "recommendPaymentCard",
+			Description: "Use this function to recommend which of the user's cards earns the most rewards for a booking category and amount.",
+		},
+		recommendPaymentCard,
+	)
+	if err != nil {
+		return fmt.Errorf("creating payment card tool: %w", err)
+	}
+
+	offsetCostTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "estimateOffsetCost",
+			Description: "Use this function to estimate the carbon footprint of the session's flights and what offsetting it costs with each provider.",
+		},
+		estimateOffsetCost,
+	)
+	if err != nil {
+		return fmt.Errorf("creating offset cost tool: %w", err)
+	}
+
+	buyOffsetTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "buyOffset",
+			Description: "Use this function to buy a carbon offset from a provider for the session's flight footprint, once the user has chosen one.",
+		},
+		buyOffset,
+	)
+	if err != nil {
+		return fmt.Errorf("creating buy offset tool: %w", err)
+	}
+
+	visaFreeTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getVisaFreeDuration",
+			Description: "Use this function to find the longest stay a traveler of a given nationality can make in a country without a visa, and whether the booked trip exceeds it.",
+		},
+		getVisaFreeDuration,
+	)
+	if err != nil {
+		return fmt.Errorf("creating visa-free duration tool: %w", err)
+	}
+
+	setBudgetTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "setTripBudget",
+			Description: "Use this function to set how much the user wants to spend on the trip; later bookings that would exceed it come back with a warning.",
+		},
+		setTripBudget,
+	)
+	if err != nil {
+		return fmt.Errorf("creating set trip budget tool: %w", err)
+	}
+
+	groupSeatsTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "arrangeGroupSeats",
+			Description: "Use this function to suggest seats that keep a travelling party together on a booked flight, with a best-effort arrangement when they cannot all sit side by side.",
+		},
+		arrangeGroupSeats,
+	)
+	if err != nil {
+		return fmt.Errorf("creating arrange group seats tool: %w", err)
+	}
+
+	spendingLogTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "getSpendingLog",
+			Description: "Use this function to list every charge made in the session, with its time and category, for a transparent record of spending.",
+		},
+		getSpendingLog,
+	)
+	if err != nil {
+		return fmt.Errorf("creating spending log tool: %w", err)
+	}
+
+	rebookingTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "findRebookingOptions",
+			Description: "Use this function when a booked flight is cancelled or disrupted to find other flights on the same route and date, including from nearby airports, earliest first.",
+		},
+		findRebookingOptions,
+	)
+	if err != nil {
+		return fmt.Errorf("creating rebooking options tool: %w", err)
+	}
+
+	modeEmissionsTool, err := functiontool.New(
+		functiontool.Config{
+			Name:        "compareModeEmissions",
+			Description: "Use this function to compare the CO2 emissions of flying and taking the train between two cities, to help the traveler choose a lower-carbon option.",
+		},
+		compareModeEmissions,
+	)
+	if err != nil {
+		return fmt.Errorf("creating compare mode emissions tool: %w", err)
+	}
+
+	// -------------------------------------------
+
+	bookerTools := []tool.Tool{hotelTool, flightTool, connectionsTool, baggageTool, expenseReportTool, airportArrivalTool, createTripTool, cancelTripTool, upgradeTool, travelTimeTool, compareDatesTool, checkInTool, splitPaymentTool, seatMapTool, boardingRefTool, layoverTool, suggestUpgradesTool, alternativeHotelsTool, bookingOrderTool, gateInfoTool, checklistTool, tripBaggageTool, petPolicyTool, perDiemTool, baggageClaimTool, suggestBundleTool, bookBundleTool, compensationTool, doorToDoorTool, tripEmailTool, comfortTool, restrictionsTool, paymentCardTool, offsetCostTool, buyOffsetTool, setBudgetTool, groupSeatsTool, spendingLogTool, rebookingTool}
+	infoTools := []tool.Tool{destinationInfoTool, jetLagTool, offPeakTool, entryRequirementsTool, restrictionsTool, visaFreeTool, modeEmissionsTool}
+	coordinatorTools := []tool.Tool{setMetadataTool, getMetadataTool, setVariableTool}
+
+	var beforeToolCallbacks []llmagent.BeforeToolCallback
+	afterToolCallbacks := []llmagent.AfterToolCallback{throttleHint}
+	if *demoLatency > 0 {
+		beforeToolCallbacks = append(beforeToolCallbacks, demoToolDelay(*demoLatency))
+	}
+	if *dedupToolCalls {
+		deduper := newToolCallDeduper()
+		beforeToolCallbacks = append(beforeToolCallbacks, deduper.beforeTool)
+		afterToolCallbacks = append(afterToolCallbacks, deduper.afterTool)
+	}
+	if *retryTools != "" {
+		// Last, so that a deduplicated call is served from the cache
+		// rather than retried.
+		retrier := newToolRetrier(*retryTools, *retryAttempts, *retryBackoff)
+		beforeToolCallbacks = append(beforeToolCallbacks, retrier.beforeTool)
+	}
+
+	var beforeModelCallbacks []llmagent.BeforeModelCallback
+	if *historyTurns > 0 {
+		beforeModelCallbacks = append(beforeModelCallbacks, historyWindow(*historyTurns))
+	}
+
+	var afterModelCallbacks []llmagent.AfterModelCallback
+	if *recoverUnknownTools {
+		guard := newUnknownToolGuard()
+		unknownTool, err := functiontool.New(
+			functiontool.Config{
+				Name:        unknownToolName,
+				Description: "Reports that a requested tool does not exist and lists the available tools. Do not call this directly.",
+			},
+			guard.reportUnknownTool,
+		)
+		if err != nil {
+			return fmt.Errorf("creating unknown tool handler: %w", err)
+		}
+		bookerTools = append(bookerTools, unknownTool)
+		infoTools = append(infoTools, unknownTool)
+		coordinatorTools = append(coordinatorTools, unknownTool)
+		guard.register("Booker", bookerTools)
+		guard.register("Info", infoTools)
+		guard.register("Coordinator", coordinatorTools)
+		afterModelCallbacks = append(afterModelCallbacks, guard.afterModel)
+	}
+
+	// --- 3. ADD TOOLS TO YOUR AGENT ---
+	coordinator, err := newCoordinator(agentSetup{
+		Models:               agentModels,
+		BookerTools:          bookerTools,
+		InfoTools:            infoTools,
+		CoordinatorTools:     coordinatorTools,
+		BeforeToolCallbacks:  beforeToolCallbacks,
+		AfterToolCallbacks:   afterToolCallbacks,
+		BeforeModelCallbacks: beforeModelCallbacks,
+		AfterModelCallbacks:  afterModelCallbacks,
 	})
 	if err != nil {
-		return fmt.Errorf("creating coordinator agent: %w", err)
+		return err
 	}
 
-	sessionService := session.InMemoryService()
+	var sessionService session.Service = session.InMemoryService()
+	if *maxSessions > 0 {
+		sessionService = newLRUSessionService(sessionService, *maxSessions)
+	}
 	runner, err := runner.New(runner.Config{
 		AppName:        "booking_planner",
 		Agent:          coordinator,
@@ -162,31 +821,98 @@ func runAgent() error {
 		log.Fatal(err)
 	}
 
+	if *warmup {
+		models.warmup(ctx)
+	}
+
+	if len(script) > 0 {
+		if failed := runScript(ctx, runner, session.Session.ID(), script); len(failed) > 0 {
+			fmt.Fprintf(os.Stderr, "\n%d demo script assertions failed:\n", len(failed))
+			for _, f := range failed {
+				fmt.Fprintf(os.Stderr, "  %s\n", f)
+			}
+			return fmt.Errorf("demo script %s failed", *demoScript)
+		}
+		fmt.Println("\nAll demo script assertions passed.")
+		if *listSessions {
+			return printSessions(ctx, sessionService, "booking_planner", "user1234")
+		}
+		return nil
+	}
+
 	run(ctx, runner, session.Session.ID(), "i want to visit in london?")
 	run(ctx, runner, session.Session.ID(), "on 2025-11-14")
 	run(ctx, runner, session.Session.ID(), "also book a hotel for me ")
 
+	if *listSessions {
+		return printSessions(ctx, sessionService, "booking_planner", "user1234")
+	}
 	return nil
 
 }
-func run(ctx context.Context, r *runner.Runner, sessionID string, prompt string) {
+
+// run sends prompt to the agent, prints the responses and returns their
+// text.
+func run(ctx context.Context, r *runner.Runner, sessionID string, prompt string) string {
 	fmt.Printf("\n> %s\n", prompt)
+	streamingMode := agent.StreamingModeNone
+	if *streamResponses {
+		streamingMode = agent.StreamingModeSSE
+	}
 	events := r.Run(
 		ctx,
 		"user1234",
 		sessionID,
 		genai.NewContentFromText(prompt, genai.RoleUser),
 		agent.RunConfig{
-			StreamingMode: agent.StreamingModeNone,
+			StreamingMode: streamingMode,
 		},
 	)
+	printer := newStreamPrinter(os.Stdout)
+	var response strings.Builder
 	for event, err := range events {
+		if errors.Is(err, errModelUnavailable) {
+			text := *degradedMessage
+			if *ruleFallback {
+				if reply, ok := respondWithoutModel(sessionID, prompt); ok {
+					text = reply
+				}
+			}
+			fmt.Printf("Agent Response: %s\n", text)
+			response.WriteString(text)
+			break
+		}
 		if err != nil {
-			log.Fatalf("ERROR during agent execution: %v", err)
+			fmt.Fprintf(os.Stderr, "Error: %s\n", describeError(err, *errorVerbosity))
+			os.Exit(1)
 		}
 
-		if event.Content.Parts[0].Text != "" {
-			fmt.Printf("Agent Response: %s\n", event.Content.Parts[0].Text)
+		if *rawEvents {
+			dumpRawEvent(os.Stdout, event)
+		}
+		if *surfaceToolErrors {
+			for _, e := range toolErrors(event.Content) {
+				fmt.Printf("Tool Error: %s\n", e)
+			}
+		}
+
+		if !event.Partial {
+			response.WriteString(eventText(event))
+		}
+		if *streamResponses {
+			printer.print(event)
+			continue
+		}
+		if raw := eventText(event); raw != "" {
+			text := formatResponse(raw, *outputFormat)
+			if *demoLatency > 0 {
+				fmt.Print("Agent Response: ")
+				printSlowly(text, *demoLatency/demoWordDelayDivisor)
+				fmt.Println()
+			} else {
+				fmt.Printf("Agent Response: %s\n", text)
+			}
 		}
 	}
+	return response.String()
 }
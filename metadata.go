@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+)
+
+// metadataStateKey is the session state key holding the session's
+// key/value metadata.
+const metadataStateKey = "session_metadata"
+
+// sessionMetadata returns a copy of the metadata stored in state.
+func sessionMetadata(state session.ReadonlyState) map[string]string {
+	metadata := make(map[string]string)
+	v, err := state.Get(metadataStateKey)
+	if err != nil {
+		return metadata
+	}
+	switch m := v.(type) {
+	case map[string]string:
+		for k, v := range m {
+			metadata[k] = v
+		}
+	case map[string]any:
+		for k, v := range m {
+			metadata[k] = fmt.Sprint(v)
+		}
+	}
+	return metadata
+}
+
+type setSessionMetadataArg struct {
+	Key   string `json:"key" jsonschema:"the metadata key, e.g. trip_purpose or company"`
+	Value string `json:"value" jsonschema:"the metadata value"`
+}
+type setSessionMetadataResult struct {
+	Status       string `json:"status"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func setSessionMetadata(c tool.Context, arg setSessionMetadataArg) setSessionMetadataResult {
+	key := strings.TrimSpace(arg.Key)
+	if key == "" {
+		return setSessionMetadataResult{Status: "error", ErrorMessage: "Metadata key must not be empty."}
+	}
+	metadata := sessionMetadata(c.State())
+	metadata[key] = arg.Value
+	if err := c.State().Set(metadataStateKey, metadata); err != nil {
+		return setSessionMetadataResult{Status: "error", ErrorMessage: fmt.Sprintf("Storing metadata: %v", err)}
+	}
+	return setSessionMetadataResult{
+		Status: "success",
+		Report: fmt.Sprintf("Session metadata %s set to %q.", key, arg.Value),
+	}
+}
+
+type getSessionMetadataArg struct{}
+type getSessionMetadataResult struct {
+	Status   string            `json:"status"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+func getSessionMetadata(c tool.Context, arg getSessionMetadataArg) getSessionMetadataResult {
+	return getSessionMetadataResult{
+		Status:   "success",
+		Metadata: sessionMetadata(c.State()),
+	}
+}
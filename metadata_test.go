@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/genai"
+)
+
+func TestSessionMetadataPersistsAcrossTurns(t *testing.T) {
+	setTool, err := functiontool.New(functiontool.Config{Name: "setSessionMetadata", Description: "set"}, setSessionMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	getTool, err := functiontool.New(functiontool.Config{Name: "getSessionMetadata", Description: "get"}, getSessionMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "Coordinator",
+		Model: newMockModel(
+			calls("setSessionMetadata", map[string]any{"key": "trip_purpose", "value": "conference"}),
+			genai.NewContentFromText("Noted.", genai.RoleModel),
+			calls("getSessionMetadata", map[string]any{}),
+		),
+		Tools: []tool.Tool{setTool, getTool},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := functionResponses(runTurns(t, a, "this trip is for a conference", "what is this trip for?"), "getSessionMetadata")
+	if len(responses) != 1 {
+		t.Fatalf("got %d getSessionMetadata responses, want 1", len(responses))
+	}
+	metadata, _ := responses[0]["metadata"].(map[string]any)
+	if got := metadata["trip_purpose"]; got != "conference" {
+		t.Errorf("trip_purpose = %v, want conference", got)
+	}
+}
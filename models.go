@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/gemini"
+	"google.golang.org/genai"
+)
+
+// modelCache constructs each Gemini model once, so agents configured with
+// the same model name share it (and its circuit breaker).
+type modelCache struct {
+	ctx    context.Context
+	apiKey string
+	models map[string]model.LLM
+}
+
+func newModelCache(ctx context.Context, apiKey string) *modelCache {
+	return &modelCache{ctx: ctx, apiKey: apiKey, models: make(map[string]model.LLM)}
+}
+
+// get returns the model with the given name, creating it on first use.
+func (c *modelCache) get(name string) (model.LLM, error) {
+	if m, ok := c.models[name]; ok {
+		return m, nil
+	}
+	m, err := gemini.NewModel(c.ctx, name, &genai.ClientConfig{
+		APIKey: c.apiKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating Gemini model %s: %w", name, err)
+	}
+	if *breakerFailures > 0 {
+		m = newBreakerModel(m, *breakerFailures, *breakerCooldown)
+	}
+	c.models[name] = m
+	return m, nil
+}
+
+// agentModelName returns the model name configured for an agent, falling
+// back to -model when override is empty.
+func agentModelName(override string) string {
+	if override != "" {
+		return override
+	}
+	return *modelName
+}
+
+// warmup makes one tiny call to each model to establish its connection
+// before the first turn, logging how long it took. Failures are logged and
+// otherwise ignored; the first turn will surface them again.
+func (c *modelCache) warmup(ctx context.Context) {
+	names := make([]string, 0, len(c.models))
+	for name := range c.models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		start := time.Now()
+		req := &model.LLMRequest{
+			Model:    name,
+			Contents: []*genai.Content{genai.NewContentFromText("ping", genai.RoleUser)},
+			Config:   &genai.GenerateContentConfig{MaxOutputTokens: 1},
+		}
+		var err error
+		for _, err = range c.models[name].GenerateContent(ctx, req, false) {
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			log.Printf("warming up %s failed after %v: %v", name, time.Since(start), err)
+			continue
+		}
+		log.Printf("warmed up %s in %v", name, time.Since(start))
+	}
+}
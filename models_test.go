@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAgentModels(t *testing.T) {
+	setFlag(t, "model", "gemini-2.5-flash")
+	setFlag(t, "booker-model", "gemini-2.5-pro")
+	setFlag(t, "info-model", "")
+
+	models := newModelCache(context.Background(), "test-key")
+	m, err := configuredAgentModels(models)
+	if err != nil {
+		t.Fatalf("configuredAgentModels: %v", err)
+	}
+	coordinator, booker, info := m.Coordinator.Name(), m.Booker.Name(), m.Info.Name()
+	if coordinator != "gemini-2.5-flash" || booker != "gemini-2.5-pro" || info != "gemini-2.5-flash" {
+		t.Errorf("models: coordinator %s, booker %s, info %s", coordinator, booker, info)
+	}
+
+	// Agents on the same model name share one instance.
+	a, _ := models.get("gemini-2.5-flash")
+	b, _ := models.get(agentModelName(""))
+	if a != b {
+		t.Error("the same model name produced two instances")
+	}
+	if len(models.models) != 2 {
+		t.Errorf("cache holds %d models, want 2", len(models.models))
+	}
+}
+
+func TestWarmupCallsEachModelOnce(t *testing.T) {
+	models := newModelCache(context.Background(), "test-key")
+	flash, pro := newMockModel(), newMockModel()
+	models.models["gemini-2.5-flash"] = flash
+	models.models["gemini-2.5-pro"] = pro
+
+	models.warmup(context.Background())
+	for name, m := range map[string]*mockModel{"gemini-2.5-flash": flash, "gemini-2.5-pro": pro} {
+		if len(m.requests) != 1 {
+			t.Errorf("%s called %d times, want once", name, len(m.requests))
+			continue
+		}
+		if req := m.requests[0]; req.Model != name || req.Config.MaxOutputTokens != 1 {
+			t.Errorf("%s warmup request = %+v, want a one-token request for it", name, req)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// busyPeriod is a canned stretch of the year when a destination is
+// crowded, with dates as MM-DD.
+type busyPeriod struct {
+	From, To string
+	Reason   string
+}
+
+var busyPeriods = map[string][]busyPeriod{
+	"london":   {{From: "06-29", To: "07-12", Reason: "Wimbledon"}, {From: "12-20", To: "12-31", Reason: "holiday season"}},
+	"paris":    {{From: "02-24", To: "03-04", Reason: "Paris Fashion Week"}, {From: "09-23", To: "10-01", Reason: "Paris Fashion Week"}},
+	"tokyo":    {{From: "03-25", To: "04-07", Reason: "cherry blossom season"}, {From: "04-29", To: "05-05", Reason: "Golden Week"}},
+	"new york": {{From: "11-25", To: "11-30", Reason: "Thanksgiving"}, {From: "12-20", To: "12-31", Reason: "holiday season"}},
+	"dubai":    {{From: "12-15", To: "01-05", Reason: "winter high season"}},
+}
+
+// Crowding added to a date's score.
+const (
+	weekendCrowding = 0.2
+	eventCrowding   = 0.4
+)
+
+// maxOffPeakRecommendations is how many dates recommendOffPeakDates returns.
+const maxOffPeakRecommendations = 5
+
+type offPeakDate struct {
+	Date   string  `json:"date"`
+	Price  float64 `json:"hotel_price"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// inPeriod reports whether the MM-DD day falls within p, which may wrap
+// around the new year.
+func inPeriod(day string, p busyPeriod) bool {
+	if p.From <= p.To {
+		return day >= p.From && day <= p.To
+	}
+	return day >= p.From || day <= p.To
+}
+
+type recommendOffPeakDatesArg struct {
+	Destination string `json:"destination" jsonschema:"the destination city"`
+	Month       string `json:"month" jsonschema:"the flexible travel month, YYYY-MM"`
+}
+type recommendOffPeakDatesResult struct {
+	Status          string        `json:"status"`
+	Recommendations []offPeakDate `json:"recommendations,omitempty"`
+	Report          string        `json:"report,omitempty"`
+	ErrorMessage    string        `json:"error_message,omitempty"`
+}
+
+func recommendOffPeakDates(c tool.Context, arg recommendOffPeakDatesArg) recommendOffPeakDatesResult {
+	month, err := time.Parse("2006-01", strings.TrimSpace(arg.Month))
+	if err != nil {
+		return recommendOffPeakDatesResult{Status: "error", ErrorMessage: fmt.Sprintf("Invalid month %q, expected YYYY-MM.", arg.Month)}
+	}
+	dest := strings.ToLower(strings.TrimSpace(arg.Destination))
+	_, knownRate := hotelRates[dest]
+	periods, knownEvents := busyPeriods[dest]
+
+	var dates []offPeakDate
+	for d := month; d.Month() == month.Month(); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dateLayout)
+		price := hotelPrice(arg.Destination, date)
+		crowding := 0.0
+		var reasons []string
+		if wd := d.Weekday(); wd == time.Friday || wd == time.Saturday {
+			crowding += weekendCrowding
+			reasons = append(reasons, "weekend demand")
+		}
+		for _, p := range periods {
+			if inPeriod(d.Format("01-02"), p) {
+				crowding += eventCrowding
+				reasons = append(reasons, p.Reason)
+			}
+		}
+		reason := "midweek, no major events"
+		if len(reasons) > 0 {
+			reason = strings.Join(reasons, ", ")
+		}
+		dates = append(dates, offPeakDate{
+			Date:   date,
+			Price:  price,
+			Score:  price * (1 + crowding),
+			Reason: reason,
+		})
+	}
+	sort.SliceStable(dates, func(i, j int) bool { return dates[i].Score < dates[j].Score })
+	if len(dates) > maxOffPeakRecommendations {
+		dates = dates[:maxOffPeakRecommendations]
+	}
+
+	report := fmt.Sprintf("Best dates to visit %s in %s, cheapest and least crowded first.", arg.Destination, month.Format("January 2006"))
+	if !knownRate && !knownEvents {
+		report = fmt.Sprintf("No seasonal data for %s; ranking %s by general weekday demand only.", arg.Destination, month.Format("January 2006"))
+	}
+	return recommendOffPeakDatesResult{
+		Status:          "success",
+		Recommendations: dates,
+		Report:          report,
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRecommendOffPeakDates(t *testing.T) {
+	c := newTestContext(t)
+	got := recommendOffPeakDates(c, recommendOffPeakDatesArg{Destination: "New York", Month: "2025-11"})
+	if got.Status != "success" || len(got.Recommendations) != maxOffPeakRecommendations {
+		t.Fatalf("got %+v, want %d recommendations", got, maxOffPeakRecommendations)
+	}
+	if !sort.SliceIsSorted(got.Recommendations, func(i, j int) bool {
+		return got.Recommendations[i].Score < got.Recommendations[j].Score
+	}) {
+		t.Errorf("recommendations are not sorted by score: %+v", got.Recommendations)
+	}
+	for _, r := range got.Recommendations {
+		if strings.Contains(r.Reason, "Thanksgiving") || strings.Contains(r.Reason, "weekend") {
+			t.Errorf("%s recommended despite %s", r.Date, r.Reason)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/adk/tool"
+)
+
+// cabinEmissionFactors scale flight emissions by fare class, since larger
+// seats take a larger share of the aircraft.
+var cabinEmissionFactors = map[string]float64{
+	"economy":         1,
+	"premium economy": 1.6,
+	"business":        2.9,
+}
+
+// flightFootprintKg estimates the CO2 of a flight booking with the same
+// per-kilometre model as compareModeEmissions.
+func flightFootprintKg(b booking) float64 {
+	factor, ok := cabinEmissionFactors[b.FareClass]
+	if !ok {
+		factor = 1
+	}
+	return flightKm(b.Origin, b.Destination) * flightKgPerKm * factor
+}
+
+// sessionFootprintKg is the estimated CO2 of the session's active flights.
+func sessionFootprintKg(sessionID string) float64 {
+	var kg float64
+	for _, f := range activeFlights(sessionID) {
+		kg += flightFootprintKg(f)
+	}
+	return math.Round(kg)
+}
+
+// unoffsetKg is the session's flight footprint not yet covered by offsets
+// bought in the session.
+func unoffsetKg(sessionID string) float64 {
+	kg := sessionFootprintKg(sessionID)
+	for _, p := range offsets.forSession(sessionID) {
+		kg -= p.KgCO2
+	}
+	return math.Max(kg, 0)
+}
+
+// offsetProviders is the canned price per tonne of CO2 by lower-case
+// provider.
+var offsetProviders = map[string]float64{
+	"climatecare":   12,
+	"gold standard": 18,
+	"climeworks":    1000,
+}
+
+func offsetCost(provider string, kg float64) float64 {
+	return math.Round(offsetProviders[provider]*kg/1000*100) / 100
+}
+
+// offsetPurchase is a carbon offset bought during a session.
+type offsetPurchase struct {
+	Provider string  `json:"provider"`
+	KgCO2    float64 `json:"kg_co2"`
+	Cost     float64 `json:"cost"`
+}
+
+// offsetStore keeps the offsets bought in each session.
+type offsetStore struct {
+	mu        sync.Mutex
+	bySession map[string][]offsetPurchase
+}
+
+var offsets = &offsetStore{bySession: make(map[string][]offsetPurchase)}
+
+func (s *offsetStore) add(sessionID string, p offsetPurchase) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bySession[sessionID] = append(s.bySession[sessionID], p)
+}
+
+func (s *offsetStore) forSession(sessionID string) []offsetPurchase {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]offsetPurchase(nil), s.bySession[sessionID]...)
+}
+
+type offsetQuote struct {
+	Provider string  `json:"provider"`
+	Cost     float64 `json:"cost"`
+}
+
+type estimateOffsetCostArg struct{}
+type estimateOffsetCostResult struct {
+	Status       string        `json:"status"`
+	KgCO2        float64       `json:"kg_co2"`
+	Quotes       []offsetQuote `json:"quotes,omitempty"`
+	Report       string        `json:"report,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+// estimateOffsetCost quotes every provider for offsetting the part of the
+// session's flight footprint that is not offset yet, cheapest first.
+func estimateOffsetCost(c tool.Context, arg estimateOffsetCostArg) estimateOffsetCostResult {
+	if sessionFootprintKg(c.SessionID()) == 0 {
+		return estimateOffsetCostResult{Status: "success", Report: "There are no flights in this session to offset."}
+	}
+	kg := unoffsetKg(c.SessionID())
+	if kg == 0 {
+		return estimateOffsetCostResult{Status: "success", Report: "The session's flights are already fully offset."}
+	}
+	var quotes []offsetQuote
+	for provider := range offsetProviders {
+		quotes = append(quotes, offsetQuote{Provider: provider, Cost: offsetCost(provider, kg)})
+	}
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].Cost < quotes[j].Cost })
+	return estimateOffsetCostResult{
+		Status: "success",
+		KgCO2:  kg,
+		Quotes: quotes,
+		Report: fmt.Sprintf("The session's flights emit about %.0f kg of CO2 not yet offset; offsetting it starts at %.2f with %s.", kg, quotes[0].Cost, quotes[0].Provider),
+	}
+}
+
+type buyOffsetArg struct {
+	Provider string `json:"provider" jsonschema:"the offset provider: climatecare, gold standard or climeworks"`
+}
+type buyOffsetResult struct {
+	Status       string          `json:"status"`
+	Purchase     *offsetPurchase `json:"purchase,omitempty"`
+	Report       string          `json:"report,omitempty"`
+	ErrorMessage string          `json:"error_message,omitempty"`
+}
+
+// buyOffset records the purchase of an offset for the part of the
+// session's flight footprint that earlier purchases do not cover.
+func buyOffset(c tool.Context, arg buyOffsetArg) buyOffsetResult {
+	provider := strings.ToLower(strings.TrimSpace(arg.Provider))
+	if _, ok := offsetProviders[provider]; !ok {
+		return buyOffsetResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown offset provider %q.", arg.Provider)}
+	}
+	if sessionFootprintKg(c.SessionID()) == 0 {
+		return buyOffsetResult{Status: "error", ErrorMessage: "There are no flights in this session to offset."}
+	}
+	kg := unoffsetKg(c.SessionID())
+	if kg == 0 {
+		return buyOffsetResult{Status: "error", ErrorMessage: "The session's flights are already fully offset."}
+	}
+	p := offsetPurchase{Provider: provider, KgCO2: kg, Cost: offsetCost(provider, kg)}
+	offsets.add(c.SessionID(), p)
+	spending.record(c.SessionID(), ledgerEntry{
+		Category:    chargeOffset,
+		Description: fmt.Sprintf("Carbon offset of %.0f kg from %s", kg, provider),
+		Amount:      p.Cost,
+	})
+	return buyOffsetResult{
+		Status:   "success",
+		Purchase: &p,
+		Report:   fmt.Sprintf("Bought an offset for %.0f kg of CO2 from %s for %.2f.", kg, provider, p.Cost),
+	}
+}
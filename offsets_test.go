@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFlightFootprintMatchesModeComparison(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	b, _ := bookings.get(c.SessionID(), confirmationOf(t, c))
+	compared := compareModeEmissions(c, compareModeEmissionsArg{Origin: "London", Destination: "Edinburgh"})
+	if got := math.Round(flightFootprintKg(b)); got != compared.FlightKgCO2 {
+		t.Errorf("economy footprint %v kg, but compareModeEmissions says %v kg", got, compared.FlightKgCO2)
+	}
+	b.FareClass = "business"
+	if got, want := flightFootprintKg(b), compared.DistanceKm*flightKgPerKm*cabinEmissionFactors["business"]; got != want {
+		t.Errorf("business footprint %v kg, want %v kg", got, want)
+	}
+}
+
+func TestOffsetCostScalesWithFootprint(t *testing.T) {
+	if small, large := offsetCost("climatecare", 500), offsetCost("climatecare", 1000); large != 2*small || large != 12 {
+		t.Errorf("offsetCost(500) = %v, offsetCost(1000) = %v, want 6 and 12", small, large)
+	}
+}
+
+func TestBuyOffsetCoversOnlyUnoffsetFlights(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Paris, France", Date: "2025-11-14"})
+	first := sessionFootprintKg(c.SessionID())
+
+	got := buyOffset(c, buyOffsetArg{Provider: "Gold Standard"})
+	if got.Status != "success" || got.Purchase.KgCO2 != first {
+		t.Fatalf("first purchase: got %+v, want %v kg", got, first)
+	}
+	if purchases := offsets.forSession(c.SessionID()); len(purchases) != 1 {
+		t.Errorf("recorded %d purchases, want 1", len(purchases))
+	}
+	if again := buyOffset(c, buyOffsetArg{Provider: "climatecare"}); again.Status != "error" {
+		t.Errorf("buying again with nothing new to offset: got %+v, want an error", again)
+	}
+
+	bookFlight(c, bookFlightArg{Origin: "Paris, France", Destination: "London", Date: "2025-11-17"})
+	quote := estimateOffsetCost(c, estimateOffsetCostArg{})
+	second := buyOffset(c, buyOffsetArg{Provider: "climatecare"})
+	want := sessionFootprintKg(c.SessionID()) - first
+	if quote.KgCO2 != want || second.Status != "success" || second.Purchase.KgCO2 != want {
+		t.Errorf("after the return flight: quoted %v kg and bought %+v, want %v kg", quote.KgCO2, second.Purchase, want)
+	}
+
+	var charged float64
+	for _, e := range spending.forSession(c.SessionID()) {
+		if e.Category == chargeOffset {
+			charged += e.Amount
+		}
+	}
+	if want := got.Purchase.Cost + second.Purchase.Cost; charged != want {
+		t.Errorf("ledger offset charges = %v, want %v", charged, want)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Output formats for -output.
+const (
+	outputMarkdown = "markdown"
+	outputPlain    = "plain"
+)
+
+var (
+	mdHeading    = regexp.MustCompile(`^#{1,6}\s+`)
+	mdBullet     = regexp.MustCompile(`^(\s*)[*+]\s+`)
+	mdQuote      = regexp.MustCompile(`^\s*>\s?`)
+	mdFence      = regexp.MustCompile("^\\s*```")
+	mdRule       = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdImage      = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdLink       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBold       = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdItalicStar = regexp.MustCompile(`(^|[^*\w])\*(\S(?:[^*]*?\S)?)\*([^*\w]|$)`)
+	mdCode       = regexp.MustCompile("`([^`]+)`")
+)
+
+// stripMarkdown converts markdown to plain text for terminals. It is
+// conservative: only unambiguous markup is removed, so text that merely
+// contains asterisks or underscores (like snake_case names) is kept.
+func stripMarkdown(text string) string {
+	lines := strings.Split(text, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if mdFence.MatchString(line) {
+			continue
+		}
+		if mdRule.MatchString(line) {
+			out = append(out, "")
+			continue
+		}
+		line = mdHeading.ReplaceAllString(line, "")
+		line = mdQuote.ReplaceAllString(line, "")
+		line = mdBullet.ReplaceAllString(line, "$1- ")
+		line = mdImage.ReplaceAllString(line, "$1 ($2)")
+		line = mdLink.ReplaceAllString(line, "$1 ($2)")
+		line = mdBold.ReplaceAllString(line, "$2")
+		line = mdItalicStar.ReplaceAllString(line, "$1$2$3")
+		line = mdCode.ReplaceAllString(line, "$1")
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// formatResponse renders model text in the configured output format.
+func formatResponse(text, format string) string {
+	if format == outputPlain {
+		return stripMarkdown(text)
+	}
+	return text
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestFormatResponse(t *testing.T) {
+	markdown := "## Your trip\n\n" +
+		"* **Flight** to _Paris_ on `2025-11-14`\n" +
+		"* Hotel: see [the booking](https://example.com/b/1)\n" +
+		"> Confirmation CONF_FLIGHT_1\n" +
+		"---\n" +
+		"```\n" +
+		"booking_ref = CONF_HOTEL_1\n" +
+		"```"
+	plain := "Your trip\n\n" +
+		"- Flight to _Paris_ on 2025-11-14\n" +
+		"- Hotel: see the booking (https://example.com/b/1)\n" +
+		"Confirmation CONF_FLIGHT_1\n" +
+		"\n" +
+		"booking_ref = CONF_HOTEL_1"
+
+	if got := formatResponse(markdown, outputPlain); got != plain {
+		t.Errorf("plain:\ngot  %q\nwant %q", got, plain)
+	}
+	if got := formatResponse(markdown, outputMarkdown); got != markdown {
+		t.Errorf("markdown: got %q, want the input unchanged", got)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// paymentTolerance absorbs rounding when comparing amounts.
+const paymentTolerance = 0.005
+
+type splitPaymentArg struct {
+	Confirmation string  `json:"confirmation" jsonschema:"the booking confirmation code"`
+	FirstMethod  string  `json:"first_method" jsonschema:"the first payment method, e.g. visa ending 1234"`
+	FirstAmount  float64 `json:"first_amount" jsonschema:"the amount charged to the first method"`
+	SecondMethod string  `json:"second_method" jsonschema:"the second payment method"`
+	SecondAmount float64 `json:"second_amount" jsonschema:"the amount charged to the second method"`
+}
+type splitPaymentResult struct {
+	Status       string    `json:"status"`
+	Total        float64   `json:"total"`
+	Payments     []payment `json:"payments,omitempty"`
+	Report       string    `json:"report,omitempty"`
+	ErrorMessage string    `json:"error_message,omitempty"`
+}
+
+func splitPayment(c tool.Context, arg splitPaymentArg) splitPaymentResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Cancelled {
+		return splitPaymentResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown confirmation code %s.", arg.Confirmation)}
+	}
+	if strings.TrimSpace(arg.FirstMethod) == "" || strings.TrimSpace(arg.SecondMethod) == "" {
+		return splitPaymentResult{Status: "error", ErrorMessage: "Both payment methods are required."}
+	}
+	if arg.FirstAmount <= 0 || arg.SecondAmount <= 0 {
+		return splitPaymentResult{Status: "error", ErrorMessage: "Both payment amounts must be positive."}
+	}
+	total := b.total()
+	if sum := arg.FirstAmount + arg.SecondAmount; math.Abs(sum-total) > paymentTolerance {
+		return splitPaymentResult{
+			Status:       "error",
+			Total:        total,
+			ErrorMessage: fmt.Sprintf("The amounts add up to %.2f but %s costs %.2f.", sum, b.Confirmation, total),
+		}
+	}
+
+	payments := []payment{
+		{Method: arg.FirstMethod, Amount: arg.FirstAmount},
+		{Method: arg.SecondMethod, Amount: arg.SecondAmount},
+	}
+	bookings.update(c.SessionID(), b.Confirmation, func(b *booking) { b.Payments = payments })
+	return splitPaymentResult{
+		Status:   "success",
+		Total:    total,
+		Payments: payments,
+		Report: fmt.Sprintf("Split %s: %.2f on %s and %.2f on %s.",
+			b.Confirmation, arg.FirstAmount, arg.FirstMethod, arg.SecondAmount, arg.SecondMethod),
+	}
+}
+
+// rewardCard is a payment card with its reward earn rates.
+type rewardCard struct {
+	Name string
+	// PointsPerUnit is the points earned per unit spent, by booking
+	// category; Base applies to other categories.
+	PointsPerUnit map[string]float64
+	Base          float64
+	// PointValue is what one point is worth when redeemed.
+	PointValue float64
+}
+
+// cardProfiles is the canned wallet of each user ID.
+var cardProfiles = map[string][]rewardCard{
+	"user1234": {
+		{Name: "Voyager Travel Rewards", PointsPerUnit: map[string]float64{"flight": 5, "hotel": 5, "travel": 3}, Base: 1, PointValue: 0.015},
+		{Name: "Everyday Cashback", PointsPerUnit: map[string]float64{"dining": 3}, Base: 1.5, PointValue: 0.01},
+		{Name: "Hotel Loyalty Card", PointsPerUnit: map[string]float64{"hotel": 10}, Base: 2, PointValue: 0.005},
+	},
+}
+
+type recommendPaymentCardArg struct {
+	Category string  `json:"category" jsonschema:"the booking category: flight, hotel, travel, dining or other"`
+	Amount   float64 `json:"amount" jsonschema:"the amount to be charged"`
+}
+type recommendPaymentCardResult struct {
+	Status       string  `json:"status"`
+	Card         string  `json:"card,omitempty"`
+	Points       float64 `json:"points"`
+	RewardValue  float64 `json:"reward_value"`
+	Report       string  `json:"report,omitempty"`
+	ErrorMessage string  `json:"error_message,omitempty"`
+}
+
+// recommendPaymentCard picks the card in the user's wallet whose rewards
+// are worth the most for the charge.
+func recommendPaymentCard(c tool.Context, arg recommendPaymentCardArg) recommendPaymentCardResult {
+	if arg.Amount <= 0 {
+		return recommendPaymentCardResult{Status: "error", ErrorMessage: "The amount must be positive."}
+	}
+	cards, ok := cardProfiles[c.UserID()]
+	if !ok || len(cards) == 0 {
+		return recommendPaymentCardResult{
+			Status: "success",
+			Report: "No cards are on file, so any card will do; a card with travel rewards usually earns the most on bookings.",
+		}
+	}
+	category := strings.ToLower(strings.TrimSpace(arg.Category))
+	var best rewardCard
+	var bestPoints, bestValue float64
+	for i, card := range cards {
+		rate, ok := card.PointsPerUnit[category]
+		if !ok {
+			rate = card.Base
+		}
+		points := arg.Amount * rate
+		if value := points * card.PointValue; i == 0 || value > bestValue {
+			best, bestPoints, bestValue = card, points, value
+		}
+	}
+	bestValue = math.Round(bestValue*100) / 100
+	return recommendPaymentCardResult{
+		Status:      "success",
+		Card:        best.Name,
+		Points:      math.Round(bestPoints),
+		RewardValue: bestValue,
+		Report:      fmt.Sprintf("Use %s: it earns about %.0f points, worth %.2f, on this %s charge.", best.Name, bestPoints, bestValue, category),
+	}
+}
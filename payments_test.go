@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestSplitPayment(t *testing.T) {
+	c := newTestContext(t)
+	bookHotel(c, bookHotelArg{Location: "London", Date: "2025-11-14"})
+	code := confirmationOf(t, c)
+	b, _ := bookings.get(c.SessionID(), code)
+
+	got := splitPayment(c, splitPaymentArg{
+		Confirmation: code,
+		FirstMethod:  "visa ending 1234", FirstAmount: 100,
+		SecondMethod: "amex ending 9876", SecondAmount: b.Price - 100,
+	})
+	if got.Status != "success" || len(got.Payments) != 2 {
+		t.Fatalf("valid split: got %+v", got)
+	}
+	if b, _ := bookings.get(c.SessionID(), code); len(b.Payments) != 2 {
+		t.Errorf("booking records %d payments, want 2", len(b.Payments))
+	}
+
+	got = splitPayment(c, splitPaymentArg{
+		Confirmation: code,
+		FirstMethod:  "visa ending 1234", FirstAmount: 100,
+		SecondMethod: "amex ending 9876", SecondAmount: 10,
+	})
+	if got.Status != "error" {
+		t.Errorf("mismatched split: Status = %q, want error", got.Status)
+	}
+}
+
+func TestRecommendPaymentCard(t *testing.T) {
+	c := newTestContext(t)
+	c.userID = "user1234"
+
+	got := recommendPaymentCard(c, recommendPaymentCardArg{Category: "travel", Amount: 200})
+	if got.Status != "success" || got.Card != "Voyager Travel Rewards" || got.Points != 600 || got.RewardValue != 9 {
+		t.Errorf("travel charge: got %+v, want the travel card earning 600 points worth 9", got)
+	}
+	if got := recommendPaymentCard(c, recommendPaymentCardArg{Category: "dining", Amount: 100}); got.Card != "Everyday Cashback" {
+		t.Errorf("dining charge: got %s, want Everyday Cashback", got.Card)
+	}
+}
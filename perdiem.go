@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// perDiemRates is the canned daily meals and incidentals allowance by
+// lower-case city.
+var perDiemRates = map[string]float64{
+	"london":    95,
+	"paris":     90,
+	"new york":  110,
+	"dubai":     85,
+	"tokyo":     100,
+	"singapore": 90,
+	"edinburgh": 70,
+}
+
+// defaultPerDiemRate is the allowance for cities missing from perDiemRates.
+const defaultPerDiemRate = 75
+
+func perDiemRate(city string) float64 {
+	if rate, ok := perDiemRates[strings.ToLower(strings.TrimSpace(city))]; ok {
+		return rate
+	}
+	return defaultPerDiemRate
+}
+
+type perDiemLine struct {
+	Confirmation string  `json:"confirmation"`
+	City         string  `json:"city"`
+	Date         string  `json:"date"`
+	Amount       float64 `json:"amount"`
+}
+
+type estimatePerDiemArg struct {
+	Apply bool `json:"apply,omitempty" jsonschema:"whether to add the per diem to the expense report"`
+}
+type estimatePerDiemResult struct {
+	Status       string        `json:"status"`
+	Nights       []perDiemLine `json:"nights,omitempty"`
+	Total        float64       `json:"total"`
+	Report       string        `json:"report,omitempty"`
+	ErrorMessage string        `json:"error_message,omitempty"`
+}
+
+// estimatePerDiem estimates meal and incidental costs for every hotel night
+// booked in the session at the rate of its city.
+func estimatePerDiem(c tool.Context, arg estimatePerDiemArg) estimatePerDiemResult {
+	var lines []perDiemLine
+	var total float64
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if b.Kind != kindHotel || b.Cancelled {
+			continue
+		}
+		rate := perDiemRate(b.Location)
+		lines = append(lines, perDiemLine{Confirmation: b.Confirmation, City: b.Location, Date: b.Date, Amount: rate})
+		total += rate
+		if arg.Apply {
+			bookings.update(c.SessionID(), b.Confirmation, func(b *booking) {
+				b.PerDiem = rate
+			})
+		}
+	}
+	if len(lines) == 0 {
+		return estimatePerDiemResult{Status: "success", Report: "There are no hotel nights in this session to estimate a per diem for."}
+	}
+	report := fmt.Sprintf("Meals and incidentals for %d nights come to %.2f.", len(lines), total)
+	if arg.Apply {
+		report += " The per diem has been added to the expense report."
+	}
+	return estimatePerDiemResult{
+		Status: "success",
+		Nights: lines,
+		Total:  total,
+		Report: report,
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestEstimatePerDiemForThreeNights(t *testing.T) {
+	c := newTestContext(t)
+	for _, date := range []string{"2025-11-14", "2025-11-15", "2025-11-16"} {
+		if got := bookHotel(c, bookHotelArg{Location: "Tokyo", Date: date}); got.Status != "success" {
+			t.Fatalf("bookHotel %s: %s", date, got.ErrorMessage)
+		}
+	}
+
+	got := estimatePerDiem(c, estimatePerDiemArg{Apply: true})
+	if got.Status != "success" || len(got.Nights) != 3 || got.Total != 300 {
+		t.Fatalf("got %+v, want three Tokyo nights totalling 300", got)
+	}
+	var meals float64
+	for _, line := range expenseLines(bookings.forSession(c.SessionID())) {
+		if line.Category == "Meals" {
+			meals += line.Amount
+		}
+	}
+	if meals != 300 {
+		t.Errorf("expense report meals = %v, want 300", meals)
+	}
+}
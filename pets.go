@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// routeAirlines is the canned operating airline by route, keyed by
+// "origin-destination" in lower case. Routes are flown by the same airline
+// in both directions.
+var routeAirlines = map[string]string{
+	"london-paris":     "Air France",
+	"london-new york":  "British Airways",
+	"london-dubai":     "Emirates",
+	"london-edinburgh": "British Airways",
+	"paris-new york":   "Air France",
+	"new york-tokyo":   "Japan Airlines",
+	"dubai-singapore":  "Emirates",
+	"singapore-sydney": "Singapore Airlines",
+}
+
+// defaultAirline operates routes missing from routeAirlines.
+const defaultAirline = "British Airways"
+
+// flightAirline returns the airline operating a flight booking.
+func flightAirline(b booking) string {
+	if airline, ok := routeAirlines[routeKey(b.Origin, b.Destination)]; ok {
+		return airline
+	}
+	if airline, ok := routeAirlines[routeKey(b.Destination, b.Origin)]; ok {
+		return airline
+	}
+	return defaultAirline
+}
+
+type petPolicy struct {
+	Cabin    string  `json:"cabin"`
+	CabinFee float64 `json:"cabin_fee"`
+	Cargo    string  `json:"cargo"`
+	CargoFee float64 `json:"cargo_fee"`
+}
+
+// petPolicies is the canned pet policy by lower-case airline. A zero fee
+// means the option is not offered.
+var petPolicies = map[string]petPolicy{
+	"british airways":    {Cabin: "Only trained assistance dogs travel in the cabin.", Cargo: "Cats and dogs travel as cargo through IAG Cargo, booked separately.", CargoFee: 450},
+	"air france":         {Cabin: "Cats and dogs up to 8 kg including carrier, max 46x28x24 cm.", CabinFee: 70, Cargo: "Larger pets travel in the heated hold.", CargoFee: 200},
+	"emirates":           {Cabin: "Falcons only on flights within the UAE; assistance dogs on request.", Cargo: "Cats and dogs travel as cargo via Emirates SkyCargo.", CargoFee: 600},
+	"japan airlines":     {Cabin: "Pets are not allowed in the cabin.", Cargo: "Cats and dogs in the hold via JAL Wan Wan Service; not offered for snub-nosed breeds.", CargoFee: 300},
+	"singapore airlines": {Cabin: "Only trained assistance dogs travel in the cabin.", Cargo: "Cats and dogs travel as checked baggage in the hold.", CargoFee: 350},
+}
+
+type getPetPolicyArg struct {
+	Airline      string `json:"airline,omitempty" jsonschema:"the airline; optional when a confirmation code is given"`
+	Confirmation string `json:"confirmation,omitempty" jsonschema:"a flight confirmation code to look up the operating airline"`
+}
+type getPetPolicyResult struct {
+	Status       string     `json:"status"`
+	Airline      string     `json:"airline,omitempty"`
+	Policy       *petPolicy `json:"policy,omitempty"`
+	Report       string     `json:"report,omitempty"`
+	ErrorMessage string     `json:"error_message,omitempty"`
+}
+
+func getPetPolicy(c tool.Context, arg getPetPolicyArg) getPetPolicyResult {
+	airline := strings.TrimSpace(arg.Airline)
+	if arg.Confirmation != "" {
+		b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+		if !ok || b.Kind != kindFlight || b.Cancelled {
+			return getPetPolicyResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+		}
+		airline = flightAirline(b)
+	}
+	if airline == "" {
+		return getPetPolicyResult{Status: "error", ErrorMessage: "Give an airline or a flight confirmation code."}
+	}
+	policy, ok := petPolicies[strings.ToLower(airline)]
+	if !ok {
+		return getPetPolicyResult{Status: "error", ErrorMessage: fmt.Sprintf("No pet policy is known for %s.", airline)}
+	}
+	return getPetPolicyResult{
+		Status:  "success",
+		Airline: airline,
+		Policy:  &policy,
+		Report:  fmt.Sprintf("Pet policy for %s.", airline),
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestGetPetPolicyByConfirmation(t *testing.T) {
+	c := newTestContext(t)
+	// Tokyo to New York is flown by Japan Airlines, in the reverse
+	// direction of the canned route.
+	bookFlight(c, bookFlightArg{Origin: "Tokyo", Destination: "New York", Date: "2025-11-14"})
+	got := getPetPolicy(c, getPetPolicyArg{Confirmation: confirmationOf(t, c)})
+	if got.Status != "success" {
+		t.Fatalf("getPetPolicy: %s", got.ErrorMessage)
+	}
+	if got.Airline != "Japan Airlines" || got.Policy == nil || got.Policy.CargoFee != 300 {
+		t.Errorf("got %s with %+v, want the Japan Airlines policy", got.Airline, got.Policy)
+	}
+
+	if got := getPetPolicy(c, getPetPolicyArg{Confirmation: "NOPE"}); got.Status != "error" {
+		t.Errorf("unknown confirmation: got status %q, want error", got.Status)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// routeFares is the canned economy fare for a route, keyed by
+// "origin-destination" in lower case. Routes are priced the same in
+// both directions.
+var routeFares = map[string]float64{
+	"london-paris":     120,
+	"london-new york":  480,
+	"london-dubai":     420,
+	"london-edinburgh": 80,
+	"paris-new york":   510,
+	"new york-tokyo":   890,
+	"dubai-singapore":  380,
+	"singapore-sydney": 450,
+}
+
+// defaultFare is the economy fare for routes missing from routeFares.
+const defaultFare = 300
+
+// fareClassMultipliers scale the economy fare for each fare class.
+var fareClassMultipliers = map[string]float64{
+	"economy":         1,
+	"premium economy": 1.8,
+	"business":        3.5,
+}
+
+// hotelRates is the canned nightly rate by lower-case city.
+var hotelRates = map[string]float64{
+	"london":    210,
+	"paris":     190,
+	"new york":  260,
+	"dubai":     170,
+	"tokyo":     180,
+	"singapore": 200,
+	"edinburgh": 140,
+}
+
+// defaultHotelRate is the nightly rate for cities missing from hotelRates.
+const defaultHotelRate = 150
+
+// monthFactors scale prices by travel month to model peak and off-peak
+// seasons.
+var monthFactors = map[time.Month]float64{
+	time.January:   0.85,
+	time.February:  0.85,
+	time.March:     0.95,
+	time.April:     1.05,
+	time.May:       1,
+	time.June:      1.15,
+	time.July:      1.3,
+	time.August:    1.3,
+	time.September: 1,
+	time.October:   0.95,
+	time.November:  0.85,
+	time.December:  1.25,
+}
+
+// weekendFlightFactor is applied to flights departing on a Friday or
+// Sunday.
+const weekendFlightFactor = 1.1
+
+// seasonalFactor returns the price factor for a travel date. Dates that do
+// not parse are priced at the base rate.
+func seasonalFactor(date string) float64 {
+	t, err := time.Parse(dateLayout, strings.TrimSpace(date))
+	if err != nil {
+		return 1
+	}
+	return monthFactors[t.Month()]
+}
+
+func flightPrice(origin, destination, fareClass, date string) float64 {
+	fare, ok := routeFares[routeKey(origin, destination)]
+	if !ok {
+		fare, ok = routeFares[routeKey(destination, origin)]
+	}
+	if !ok {
+		fare = defaultFare
+	}
+	multiplier, ok := fareClassMultipliers[fareClass]
+	if !ok {
+		multiplier = 1
+	}
+	price := fare * multiplier * seasonalFactor(date)
+	if t, err := time.Parse(dateLayout, strings.TrimSpace(date)); err == nil {
+		if wd := t.Weekday(); wd == time.Friday || wd == time.Sunday {
+			price *= weekendFlightFactor
+		}
+	}
+	return math.Round(price*100) / 100
+}
+
+func hotelPrice(location, date string) float64 {
+	rate, ok := hotelRates[strings.ToLower(strings.TrimSpace(location))]
+	if !ok {
+		rate = defaultHotelRate
+	}
+	return math.Round(rate*seasonalFactor(date)*100) / 100
+}
+
+// total returns the booking price plus its fees.
+func (b booking) total() float64 {
+	total := b.Price
+	for _, fee := range b.Fees {
+		total += fee
+	}
+	return total
+}
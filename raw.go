@@ -0,0 +1,20 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"google.golang.org/adk/session"
+)
+
+// dumpRawEvent writes event as received from the runner, pretty-printed as
+// JSON with every part type included.
+func dumpRawEvent(w io.Writer, event *session.Event) {
+	data, err := json.MarshalIndent(event, "", "  ")
+	if err != nil {
+		fmt.Fprintf(w, "Raw Event: (cannot encode: %v)\n", err)
+		return
+	}
+	fmt.Fprintf(w, "Raw Event: %s\n", data)
+}
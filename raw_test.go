@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/adk/session"
+)
+
+func TestDumpRawEvent(t *testing.T) {
+	event := session.NewEvent("invocation")
+	event.Author = "Booker"
+	event.Content = calls("bookFlight", map[string]any{"destination": "Lisbon"})
+
+	var buf bytes.Buffer
+	dumpRawEvent(&buf, event)
+	out := buf.String()
+	if !strings.HasPrefix(out, "Raw Event: {") {
+		t.Fatalf("output %q does not start with the JSON event", out)
+	}
+	for _, want := range []string{`"Booker"`, `"bookFlight"`, `"Lisbon"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dump is missing %s:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// cityAirports lists the airports serving a lower-case city, main airport
+// first.
+var cityAirports = map[string][]string{
+	"london":   {"LHR", "LGW", "STN"},
+	"paris":    {"CDG", "ORY"},
+	"new york": {"JFK", "EWR", "LGA"},
+	"tokyo":    {"HND", "NRT"},
+	"dubai":    {"DXB", "DWC"},
+}
+
+// alternateFlight is a canned departure on a route.
+type alternateFlight struct {
+	From, To string
+	Depart   string
+}
+
+// alternateFlights is the canned same-day schedule by route, keyed by
+// "origin-destination" in lower case.
+var alternateFlights = map[string][]alternateFlight{
+	"london-paris": {
+		{From: "LHR", To: "CDG", Depart: "12:40"},
+		{From: "LGW", To: "CDG", Depart: "09:55"},
+		{From: "LHR", To: "ORY", Depart: "16:10"},
+		{From: "STN", To: "ORY", Depart: "19:30"},
+	},
+	"paris-london": {
+		{From: "CDG", To: "LHR", Depart: "07:30"},
+		{From: "ORY", To: "LGW", Depart: "14:45"},
+	},
+	"london-new york": {
+		{From: "LHR", To: "JFK", Depart: "15:20"},
+		{From: "LGW", To: "EWR", Depart: "13:05"},
+		{From: "LHR", To: "EWR", Depart: "18:45"},
+	},
+	"new york-london": {
+		{From: "JFK", To: "LHR", Depart: "21:30"},
+		{From: "EWR", To: "LGW", Depart: "17:50"},
+	},
+	"london-dubai": {
+		{From: "LGW", To: "DXB", Depart: "14:15"},
+		{From: "LHR", To: "DWC", Depart: "09:40"},
+	},
+	"new york-tokyo": {
+		{From: "JFK", To: "NRT", Depart: "10:50"},
+		{From: "EWR", To: "HND", Depart: "16:35"},
+	},
+}
+
+// mainAirport returns the first airport serving city, or "" if unknown.
+func mainAirport(city string) string {
+	if airports := cityAirports[strings.ToLower(strings.TrimSpace(city))]; len(airports) > 0 {
+		return airports[0]
+	}
+	return ""
+}
+
+type rebookingOption struct {
+	FromAirport string `json:"from_airport"`
+	ToAirport   string `json:"to_airport"`
+	Departure   string `json:"departure"`
+	// NearbyAirport is set when either airport is not the city's main one.
+	NearbyAirport bool `json:"nearby_airport"`
+}
+
+type findRebookingOptionsArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type findRebookingOptionsResult struct {
+	Status       string            `json:"status"`
+	Cancelled    bool              `json:"cancelled"`
+	Options      []rebookingOption `json:"options,omitempty"`
+	Report       string            `json:"report,omitempty"`
+	ErrorMessage string            `json:"error_message,omitempty"`
+}
+
+// findRebookingOptions lists other departures on the booking's route and
+// date, including from nearby airports, earliest first.
+func findRebookingOptions(c tool.Context, arg findRebookingOptionsArg) findRebookingOptionsResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return findRebookingOptionsResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	from, to := mainAirport(b.Origin), mainAirport(b.Destination)
+	var options []rebookingOption
+	for _, f := range alternateFlights[routeKey(b.Origin, b.Destination)] {
+		options = append(options, rebookingOption{
+			FromAirport:   f.From,
+			ToAirport:     f.To,
+			Departure:     b.Date + " " + f.Depart,
+			NearbyAirport: f.From != from || f.To != to,
+		})
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Departure < options[j].Departure })
+
+	state := flightStatus(b)
+	result := findRebookingOptionsResult{Status: "success", Cancelled: state.Cancelled, Options: options}
+	status := "is operating as scheduled"
+	switch {
+	case state.Cancelled:
+		status = "has been cancelled"
+	case state.Delay > 0:
+		status = fmt.Sprintf("is delayed by %d minutes", int(state.Delay.Minutes()))
+	}
+	if len(options) == 0 {
+		result.Report = fmt.Sprintf("%s from %s to %s %s. No alternative flights are available on %s.", b.Confirmation, b.Origin, b.Destination, status, b.Date)
+		return result
+	}
+	result.Report = fmt.Sprintf("%s from %s to %s %s. %d alternative flights depart on %s; the earliest leaves %s at %s.",
+		b.Confirmation, b.Origin, b.Destination, status, len(options), b.Date, options[0].FromAirport, options[0].Departure)
+	return result
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRebookingOptionsForCancelledFlight(t *testing.T) {
+	c := newTestContext(t)
+	b := bookFlightWhere(t, c, func(b booking) bool { return flightStatus(b).Cancelled })
+
+	got := findRebookingOptions(c, findRebookingOptionsArg{Confirmation: b.Confirmation})
+	if got.Status != "success" || !got.Cancelled {
+		t.Fatalf("got %+v, want a cancelled flight", got)
+	}
+	var departures []string
+	for _, o := range got.Options {
+		departures = append(departures, o.Departure)
+	}
+	want := []string{b.Date + " 09:55", b.Date + " 12:40", b.Date + " 16:10", b.Date + " 19:30"}
+	if !slices.Equal(departures, want) {
+		t.Errorf("departures = %v, want %v", departures, want)
+	}
+	// Only the 12:40 from LHR to CDG uses both main airports.
+	for _, o := range got.Options {
+		if main := o.FromAirport == "LHR" && o.ToAirport == "CDG"; o.NearbyAirport == main {
+			t.Errorf("%s %s -> %s: NearbyAirport = %v", o.Departure, o.FromAirport, o.ToAirport, o.NearbyAirport)
+		}
+	}
+}
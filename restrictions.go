@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// anyCountry matches every country in a travelRestriction.
+const anyCountry = "*"
+
+// travelRestriction is a canned entry restriction or ban between two
+// countries, in effect from From until Until inclusive. Empty dates leave
+// that end open.
+type travelRestriction struct {
+	Origin      string
+	Destination string
+	From, Until string
+	Kind        string
+	Detail      string
+}
+
+// travelRestrictions is the canned restriction list. Countries are lower
+// case.
+var travelRestrictions = []travelRestriction{
+	{Origin: anyCountry, Destination: "north korea", Kind: "ban", Detail: "Entry for tourism is suspended."},
+	{Origin: "united states", Destination: "cuba", Kind: "restriction", Detail: "Tourist travel is prohibited under US regulations; travel must fall under an authorised category."},
+	{Origin: anyCountry, Destination: "united kingdom", From: "2025-04-02", Kind: "requirement", Detail: "Most visa-exempt visitors need an Electronic Travel Authorisation (ETA) before travelling."},
+	{Origin: anyCountry, Destination: "kenya", From: "2024-01-01", Kind: "requirement", Detail: "All visitors need an Electronic Travel Authorisation (eTA) before travelling."},
+}
+
+// applies reports whether r covers travel from origin to destination on
+// date.
+func (r travelRestriction) applies(origin, destination string, date time.Time) bool {
+	if r.Origin != anyCountry && r.Origin != origin || r.Destination != anyCountry && r.Destination != destination {
+		return false
+	}
+	if origin == destination {
+		return false
+	}
+	if from, err := time.Parse(dateLayout, r.From); err == nil && date.Before(from) {
+		return false
+	}
+	if until, err := time.Parse(dateLayout, r.Until); err == nil && date.After(until) {
+		return false
+	}
+	return true
+}
+
+type restrictionNotice struct {
+	Kind   string `json:"kind"`
+	Detail string `json:"detail"`
+}
+
+type checkTravelRestrictionsArg struct {
+	OriginCountry      string `json:"origin_country" jsonschema:"the country the traveler departs from"`
+	DestinationCountry string `json:"destination_country" jsonschema:"the country the traveler is going to"`
+	Date               string `json:"date" jsonschema:"the travel date, YYYY-MM-DD"`
+}
+type checkTravelRestrictionsResult struct {
+	Status       string              `json:"status"`
+	Restricted   bool                `json:"restricted"`
+	Restrictions []restrictionNotice `json:"restrictions,omitempty"`
+	Report       string              `json:"report,omitempty"`
+	ErrorMessage string              `json:"error_message,omitempty"`
+}
+
+func checkTravelRestrictions(c tool.Context, arg checkTravelRestrictionsArg) checkTravelRestrictionsResult {
+	date, err := time.Parse(dateLayout, strings.TrimSpace(arg.Date))
+	if err != nil {
+		return checkTravelRestrictionsResult{Status: "error", ErrorMessage: fmt.Sprintf("Invalid date %q, expected YYYY-MM-DD.", arg.Date)}
+	}
+	origin := strings.ToLower(strings.TrimSpace(arg.OriginCountry))
+	destination := strings.ToLower(strings.TrimSpace(arg.DestinationCountry))
+
+	var notices []restrictionNotice
+	restricted := false
+	for _, r := range travelRestrictions {
+		if r.applies(origin, destination, date) {
+			notices = append(notices, restrictionNotice{Kind: r.Kind, Detail: r.Detail})
+			restricted = restricted || r.Kind == "ban" || r.Kind == "restriction"
+		}
+	}
+	if len(notices) == 0 {
+		return checkTravelRestrictionsResult{
+			Status: "success",
+			Report: fmt.Sprintf("No restrictions apply to travel from %s to %s on %s.", arg.OriginCountry, arg.DestinationCountry, arg.Date),
+		}
+	}
+	return checkTravelRestrictionsResult{
+		Status:       "success",
+		Restricted:   restricted,
+		Restrictions: notices,
+		Report:       fmt.Sprintf("%d entry rules apply to travel from %s to %s on %s; warn the traveler before booking.", len(notices), arg.OriginCountry, arg.DestinationCountry, arg.Date),
+	}
+}
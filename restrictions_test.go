@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestCheckTravelRestrictions(t *testing.T) {
+	c := newTestContext(t)
+	tests := []struct {
+		origin, destination, date string
+		restricted                bool
+		notices                   int
+	}{
+		{"United States", "Cuba", "2025-11-14", true, 1},
+		{"France", "Cuba", "2025-11-14", false, 0},
+		// The UK ETA is a requirement, not a restriction, and only from
+		// April 2025.
+		{"France", "United Kingdom", "2025-11-14", false, 1},
+		{"France", "United Kingdom", "2025-01-10", false, 0},
+	}
+	for _, tt := range tests {
+		got := checkTravelRestrictions(c, checkTravelRestrictionsArg{OriginCountry: tt.origin, DestinationCountry: tt.destination, Date: tt.date})
+		if got.Status != "success" || got.Restricted != tt.restricted || len(got.Restrictions) != tt.notices {
+			t.Errorf("%s -> %s on %s: got restricted %v with %d notices, want %v with %d",
+				tt.origin, tt.destination, tt.date, got.Restricted, len(got.Restrictions), tt.restricted, tt.notices)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// retryableErrorCodes are the error_code values of tool error results that
+// indicate a transient failure of an external service. Any other error,
+// such as an invalid date, is returned without retrying.
+var retryableErrorCodes = map[string]bool{
+	"Unavailable":   true,
+	"Timeout":       true,
+	"RateLimited":   true,
+	"QuotaExceeded": true,
+}
+
+// runnableTool is implemented by function tools.
+type runnableTool interface {
+	tool.Tool
+	Run(ctx tool.Context, args any) (map[string]any, error)
+}
+
+// toolRetrier runs the configured tools itself, retrying transient
+// failures with exponential backoff.
+type toolRetrier struct {
+	tools    map[string]bool
+	attempts int
+	backoff  time.Duration
+}
+
+// newToolRetrier retries the tools in the comma-separated list up to
+// attempts times in total, waiting backoff before the first retry and
+// doubling the wait after each one.
+func newToolRetrier(names string, attempts int, backoff time.Duration) *toolRetrier {
+	tools := make(map[string]bool)
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			tools[name] = true
+		}
+	}
+	return &toolRetrier{tools: tools, attempts: attempts, backoff: backoff}
+}
+
+// beforeTool runs a retryable tool and returns its final result, or nil to
+// let any other tool run normally.
+func (r *toolRetrier) beforeTool(ctx tool.Context, t tool.Tool, args map[string]any) (map[string]any, error) {
+	rt, ok := t.(runnableTool)
+	if !ok || !r.tools[t.Name()] {
+		return nil, nil
+	}
+	wait := r.backoff
+	for attempt := 1; ; attempt++ {
+		result, err := rt.Run(ctx, args)
+		if attempt >= r.attempts || !transientFailure(result, err) {
+			return result, err
+		}
+		delay := wait
+		if after := time.Duration(retryAfterSeconds(result) * float64(time.Second)); after > delay {
+			delay = after
+		}
+		log.Printf("%s failed on attempt %d of %d, retrying in %v", t.Name(), attempt, r.attempts, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("retrying %s: %w", t.Name(), ctx.Err())
+		}
+		wait *= 2
+	}
+}
+
+// transientFailure reports whether a tool call failed in a way worth
+// retrying: the tool itself returned an error, or its result carries a
+// retryable error code.
+func transientFailure(result map[string]any, err error) bool {
+	if err != nil {
+		return true
+	}
+	if result["status"] != "error" {
+		return false
+	}
+	code, _ := result["error_code"].(string)
+	return retryableErrorCodes[code]
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type flakyResult struct {
+	Status    string `json:"status"`
+	ErrorCode string `json:"error_code,omitempty"`
+}
+
+func TestToolRetrierRetriesTransientFailure(t *testing.T) {
+	runs := 0
+	flaky, err := functiontool.New(functiontool.Config{Name: "flaky", Description: "fails once"},
+		func(tool.Context, countArg) flakyResult {
+			runs++
+			if runs == 1 {
+				return flakyResult{Status: "error", ErrorCode: "Unavailable"}
+			}
+			return flakyResult{Status: "success"}
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retrier := newToolRetrier("flaky", 3, 0)
+	result, err := retrier.beforeTool(newTestContext(t), flaky, map[string]any{"item": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["status"] != "success" {
+		t.Errorf("result = %v, want success after a retry", result)
+	}
+	if runs != 2 {
+		t.Errorf("tool ran %d times, want 2", runs)
+	}
+}
+
+func TestToolRetrierSkipsOtherTools(t *testing.T) {
+	other, err := functiontool.New(functiontool.Config{Name: "other", Description: "not retried"},
+		func(tool.Context, countArg) flakyResult { return flakyResult{Status: "success"} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := newToolRetrier("flaky", 3, 0).beforeTool(newTestContext(t), other, map[string]any{})
+	if result != nil || err != nil {
+		t.Errorf("beforeTool = %v, %v, want nil so the tool runs normally", result, err)
+	}
+}
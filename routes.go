@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// hubRoutes is a canned graph of direct flights between cities. Every
+// route is listed once and treated as bidirectional.
+var hubRoutes = map[string][]string{
+	"london":    {"paris", "new york", "dubai", "frankfurt", "edinburgh", "reykjavik"},
+	"paris":     {"new york", "dubai", "frankfurt", "nice", "marrakesh"},
+	"frankfurt": {"new york", "dubai", "singapore", "krakow"},
+	"dubai":     {"singapore", "mumbai", "nairobi", "tokyo"},
+	"singapore": {"tokyo", "sydney", "bali"},
+	"new york":  {"tokyo", "boston", "reykjavik", "san juan"},
+}
+
+// hubs are the cities connections may be routed through.
+var hubs = []string{"london", "paris", "frankfurt", "dubai", "singapore", "new york"}
+
+func hasDirectRoute(from, to string) bool {
+	for _, dest := range hubRoutes[from] {
+		if dest == to {
+			return true
+		}
+	}
+	for _, dest := range hubRoutes[to] {
+		if dest == from {
+			return true
+		}
+	}
+	return false
+}
+
+type suggestConnectionsArg struct {
+	Origin      string `json:"origin" jsonschema:"the origin city of the trip"`
+	Destination string `json:"destination" jsonschema:"the destination city of the trip"`
+}
+type suggestConnectionsResult struct {
+	Status       string   `json:"status"`
+	Routes       []string `json:"routes,omitempty"`
+	Report       string   `json:"report,omitempty"`
+	ErrorMessage string   `json:"error_message,omitempty"`
+}
+
+func suggestConnections(c tool.Context, arg suggestConnectionsArg) suggestConnectionsResult {
+	origin := strings.ToLower(strings.TrimSpace(arg.Origin))
+	destination := strings.ToLower(strings.TrimSpace(arg.Destination))
+
+	var routes []string
+	for _, hub := range hubs {
+		if hub == origin || hub == destination {
+			continue
+		}
+		if hasDirectRoute(origin, hub) && hasDirectRoute(hub, destination) {
+			routes = append(routes, fmt.Sprintf("%s -> %s -> %s", arg.Origin, hub, arg.Destination))
+		}
+	}
+	sort.Strings(routes)
+
+	if len(routes) == 0 {
+		return suggestConnectionsResult{
+			Status:       "error",
+			ErrorMessage: fmt.Sprintf("No one-stop route found from %s to %s.", arg.Origin, arg.Destination),
+		}
+	}
+	return suggestConnectionsResult{
+		Status: "success",
+		Routes: routes,
+		Report: fmt.Sprintf("Found %d one-stop route(s) from %s to %s.", len(routes), arg.Origin, arg.Destination),
+	}
+}
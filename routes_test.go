@@ -0,0 +1,23 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSuggestConnections(t *testing.T) {
+	c := newTestContext(t)
+
+	// Krakow is only served from Frankfurt, which flies to Dubai.
+	got := suggestConnections(c, suggestConnectionsArg{Origin: "Krakow", Destination: "Dubai"})
+	if got.Status != "success" {
+		t.Fatalf("Status = %q (%s), want success", got.Status, got.ErrorMessage)
+	}
+	if want := []string{"Krakow -> frankfurt -> Dubai"}; !slices.Equal(got.Routes, want) {
+		t.Errorf("Routes = %v, want %v", got.Routes, want)
+	}
+
+	if got := suggestConnections(c, suggestConnectionsArg{Origin: "Bali", Destination: "Boston"}); got.Status != "error" {
+		t.Errorf("Bali to Boston: Status = %q, want error", got.Status)
+	}
+}
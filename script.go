@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/adk/runner"
+)
+
+// assertPrefix starts a script line holding text the previous prompt's
+// response must contain.
+const assertPrefix = ">assert "
+
+// scriptStep is a prompt of a demo script with the assertions on its
+// response.
+type scriptStep struct {
+	Line    int
+	Prompt  string
+	Asserts []string
+}
+
+// loadDemoScript reads a demo script: one prompt per line, each optionally
+// followed by ">assert <text>" lines. Blank lines and lines starting with
+// "#" are ignored.
+func loadDemoScript(path string) ([]scriptStep, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening demo script: %w", err)
+	}
+	defer f.Close()
+
+	var steps []scriptStep
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, assertPrefix):
+			if len(steps) == 0 {
+				return nil, fmt.Errorf("%s:%d: assertion before the first prompt", path, n)
+			}
+			expected := strings.TrimSpace(strings.TrimPrefix(line, assertPrefix))
+			if expected == "" {
+				return nil, fmt.Errorf("%s:%d: empty assertion", path, n)
+			}
+			steps[len(steps)-1].Asserts = append(steps[len(steps)-1].Asserts, expected)
+		default:
+			steps = append(steps, scriptStep{Line: n, Prompt: line})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading demo script: %w", err)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("demo script %s has no prompts", path)
+	}
+	return steps, nil
+}
+
+// failedAssertions returns a line for each assertion of step that
+// response does not contain.
+func failedAssertions(step scriptStep, response string) []string {
+	var failed []string
+	for _, expected := range step.Asserts {
+		if !strings.Contains(response, expected) {
+			failed = append(failed, fmt.Sprintf("line %d %q: response does not contain %q", step.Line, step.Prompt, expected))
+		}
+	}
+	return failed
+}
+
+// runScript runs the steps of a demo script as turns of one session and
+// returns the assertions their responses failed.
+func runScript(ctx context.Context, r *runner.Runner, sessionID string, steps []scriptStep) []string {
+	var failed []string
+	for _, step := range steps {
+		response := run(ctx, r, sessionID, step.Prompt)
+		failed = append(failed, failedAssertions(step, response)...)
+	}
+	return failed
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/genai"
+)
+
+func TestDemoScriptAssertions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.txt")
+	script := "# booking demo\n" +
+		"book a flight to Lisbon\n" +
+		">assert confirmation\n" +
+		">assert Lisbon\n" +
+		"\n" +
+		"thanks\n"
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	steps, err := loadDemoScript(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 2 || steps[0].Line != 2 || len(steps[0].Asserts) != 2 || len(steps[1].Asserts) != 0 {
+		t.Fatalf("loaded %+v, want two prompts with two assertions on the first", steps)
+	}
+
+	failed := failedAssertions(steps[0], "Your confirmation code is ABC123 for Porto.")
+	if len(failed) != 1 {
+		t.Errorf("failedAssertions = %q, want only the Lisbon assertion to fail", failed)
+	}
+}
+
+func TestLoadDemoScriptRejectsLeadingAssertion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.txt")
+	if err := os.WriteFile(path, []byte(">assert hello\nhi\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadDemoScript(path); err == nil {
+		t.Error("loadDemoScript accepted an assertion before the first prompt")
+	}
+}
+
+func TestRunScriptReportsFailedAssertions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.txt")
+	script := "book a flight to London\n" +
+		">assert London\n" +
+		"thanks\n" +
+		">assert goodbye\n"
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	steps, err := loadDemoScript(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newMockModel(
+		genai.NewContentFromText("Your flight to London is booked.", genai.RoleModel),
+		genai.NewContentFromText("You're welcome.", genai.RoleModel),
+	)
+	a, err := llmagent.New(llmagent.Config{Name: "Coordinator", Model: m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, sessionID := newRunSession(t, a)
+
+	failed := runScript(context.Background(), r, sessionID, steps)
+	if len(failed) != 1 || !strings.Contains(failed[0], `"thanks"`) || !strings.Contains(failed[0], `"goodbye"`) {
+		t.Errorf("runScript failed = %q, want only the goodbye assertion", failed)
+	}
+	if len(m.requests) != 2 {
+		t.Errorf("model got %d requests, want one per step", len(m.requests))
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// cabinLayout describes the rows and seat letters of a cabin.
+type cabinLayout struct {
+	FirstRow, LastRow int
+	Letters           string
+	// AisleAfter is the number of letters left of the aisle.
+	AisleAfter int
+}
+
+// cabinLayouts is the canned seat layout by fare class.
+var cabinLayouts = map[string]cabinLayout{
+	"business":        {FirstRow: 1, LastRow: 4, Letters: "ACDF", AisleAfter: 2},
+	"premium economy": {FirstRow: 5, LastRow: 8, Letters: "ABCDEF", AisleAfter: 3},
+	"economy":         {FirstRow: 10, LastRow: 29, Letters: "ABCDEF", AisleAfter: 3},
+}
+
+// occupancyPercent is the share of seats other passengers hold.
+const occupancyPercent = 45
+
+// seatMap is the seat availability of the cabin a flight booking is in.
+type seatMap struct {
+	Cabin    string
+	Layout   cabinLayout
+	Occupied map[string]bool
+}
+
+// seatMapFor returns the canned seat map of the booking's cabin. Occupancy
+// depends only on the flight (route and date), so every booking on the
+// same flight sees the same map. The booking's own seat is not counted as
+// occupied.
+func seatMapFor(b booking) seatMap {
+	layout, ok := cabinLayouts[b.FareClass]
+	if !ok {
+		layout = cabinLayouts["economy"]
+	}
+	flight := routeKey(b.Origin, b.Destination) + "@" + b.Date
+	occupied := make(map[string]bool)
+	for _, seat := range layout.seats() {
+		h := fnv.New32a()
+		h.Write([]byte(flight + "/" + seat))
+		if h.Sum32()%100 < occupancyPercent && seat != b.Seat {
+			occupied[seat] = true
+		}
+	}
+	return seatMap{Cabin: b.FareClass, Layout: layout, Occupied: occupied}
+}
+
+// seats returns every seat of the layout in row order.
+func (l cabinLayout) seats() []string {
+	var seats []string
+	for row := l.FirstRow; row <= l.LastRow; row++ {
+		for _, letter := range l.Letters {
+			seats = append(seats, fmt.Sprintf("%d%c", row, letter))
+		}
+	}
+	return seats
+}
+
+// free returns the unoccupied seats in row order.
+func (m seatMap) free() []string {
+	var free []string
+	for _, seat := range m.Layout.seats() {
+		if !m.Occupied[seat] {
+			free = append(free, seat)
+		}
+	}
+	return free
+}
+
+// render draws the map one row per line: "." is free, "X" is occupied and
+// "*" is yours.
+func (m seatMap) render(yours string) string {
+	var sb strings.Builder
+	sb.WriteString("    ")
+	for i, letter := range m.Layout.Letters {
+		if i == m.Layout.AisleAfter {
+			sb.WriteString("  ")
+		}
+		fmt.Fprintf(&sb, "%c ", letter)
+	}
+	sb.WriteString("\n")
+	for row := m.Layout.FirstRow; row <= m.Layout.LastRow; row++ {
+		fmt.Fprintf(&sb, "%3d ", row)
+		for i, letter := range m.Layout.Letters {
+			if i == m.Layout.AisleAfter {
+				sb.WriteString("  ")
+			}
+			seat := fmt.Sprintf("%d%c", row, letter)
+			switch {
+			case seat == yours:
+				sb.WriteString("* ")
+			case m.Occupied[seat]:
+				sb.WriteString("X ")
+			default:
+				sb.WriteString(". ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// assignSeat picks a stable free seat for a booking that has none selected.
+func assignSeat(b booking) string {
+	free := seatMapFor(b).free()
+	if len(free) == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	h.Write([]byte(b.Confirmation))
+	return free[h.Sum32()%uint32(len(free))]
+}
+
+type getSeatMapArg struct {
+	Confirmation string `json:"confirmation" jsonschema:"the flight confirmation code"`
+}
+type getSeatMapResult struct {
+	Status       string   `json:"status"`
+	Cabin        string   `json:"cabin,omitempty"`
+	FreeSeats    []string `json:"free_seats,omitempty"`
+	Occupied     []string `json:"occupied_seats,omitempty"`
+	YourSeat     string   `json:"your_seat,omitempty"`
+	Map          string   `json:"map,omitempty"`
+	ErrorMessage string   `json:"error_message,omitempty"`
+}
+
+func getSeatMap(c tool.Context, arg getSeatMapArg) getSeatMapResult {
+	b, ok := bookings.get(c.SessionID(), arg.Confirmation)
+	if !ok || b.Kind != kindFlight || b.Cancelled {
+		return getSeatMapResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown flight confirmation code %s.", arg.Confirmation)}
+	}
+	m := seatMapFor(b)
+	var occupied []string
+	for _, seat := range m.Layout.seats() {
+		if m.Occupied[seat] {
+			occupied = append(occupied, seat)
+		}
+	}
+	return getSeatMapResult{
+		Status:    "success",
+		Cabin:     m.Cabin,
+		FreeSeats: m.free(),
+		Occupied:  occupied,
+		YourSeat:  b.Seat,
+		Map:       m.render(b.Seat),
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGetSeatMap(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Dubai", Date: "2025-11-14", FareClass: "business"})
+	code := confirmationOf(t, c)
+	bookings.update(c.SessionID(), code, func(b *booking) { b.Seat = "1A" })
+
+	got := getSeatMap(c, getSeatMapArg{Confirmation: code})
+	if got.Status != "success" || got.Cabin != "business" {
+		t.Fatalf("got %+v, want the business cabin", got)
+	}
+	seats := cabinLayouts["business"].seats()
+	if len(got.FreeSeats)+len(got.Occupied) != len(seats) {
+		t.Errorf("%d free and %d occupied seats, want %d in total", len(got.FreeSeats), len(got.Occupied), len(seats))
+	}
+	for _, seat := range got.Occupied {
+		if slices.Contains(got.FreeSeats, seat) {
+			t.Errorf("seat %s is both free and occupied", seat)
+		}
+	}
+	// Your own seat is never shown as taken.
+	if slices.Contains(got.Occupied, "1A") || !slices.Contains(got.FreeSeats, "1A") {
+		t.Errorf("your seat 1A: occupied %v, free %v", got.Occupied, got.FreeSeats)
+	}
+
+	// Occupancy depends only on the flight, so another booking on it sees
+	// the same taken seats.
+	other := seatMapFor(booking{Origin: "London", Destination: "Dubai", Date: "2025-11-14", FareClass: "business"})
+	for _, seat := range got.Occupied {
+		if !other.Occupied[seat] {
+			t.Errorf("seat %s is occupied for one booking but not another on the same flight", seat)
+		}
+	}
+}
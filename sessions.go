@@ -0,0 +1,112 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"log"
+	"sync"
+
+	"google.golang.org/adk/session"
+)
+
+// sessionKey identifies a session across apps and users.
+type sessionKey struct {
+	appName   string
+	userID    string
+	sessionID string
+}
+
+// lruSessionService wraps a session.Service and bounds the number of
+// sessions it keeps, evicting the least-recently-used one once the limit
+// is exceeded. There is no persistent store behind the in-memory service,
+// so evicted sessions are dropped.
+type lruSessionService struct {
+	session.Service
+
+	maxSessions int
+
+	mu    sync.Mutex
+	order *list.List // front is most recently used
+	elems map[sessionKey]*list.Element
+}
+
+func newLRUSessionService(inner session.Service, maxSessions int) *lruSessionService {
+	return &lruSessionService{
+		Service:     inner,
+		maxSessions: maxSessions,
+		order:       list.New(),
+		elems:       make(map[sessionKey]*list.Element),
+	}
+}
+
+func (s *lruSessionService) Create(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	resp, err := s.Service.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.touch(ctx, keyOf(resp.Session))
+	return resp, nil
+}
+
+func (s *lruSessionService) Get(ctx context.Context, req *session.GetRequest) (*session.GetResponse, error) {
+	resp, err := s.Service.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.touch(ctx, keyOf(resp.Session))
+	return resp, nil
+}
+
+func (s *lruSessionService) Delete(ctx context.Context, req *session.DeleteRequest) error {
+	if err := s.Service.Delete(ctx, req); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := sessionKey{appName: req.AppName, userID: req.UserID, sessionID: req.SessionID}
+	if elem, ok := s.elems[key]; ok {
+		s.order.Remove(elem)
+		delete(s.elems, key)
+	}
+	return nil
+}
+
+func (s *lruSessionService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
+	if err := s.Service.AppendEvent(ctx, sess, event); err != nil {
+		return err
+	}
+	s.touch(ctx, keyOf(sess))
+	return nil
+}
+
+// touch marks the session as most recently used and evicts the least
+// recently used sessions while the limit is exceeded.
+func (s *lruSessionService) touch(ctx context.Context, key sessionKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.elems[key]; ok {
+		s.order.MoveToFront(elem)
+	} else {
+		s.elems[key] = s.order.PushFront(key)
+	}
+
+	for s.maxSessions > 0 && s.order.Len() > s.maxSessions {
+		oldest := s.order.Back()
+		victim := oldest.Value.(sessionKey)
+		s.order.Remove(oldest)
+		delete(s.elems, victim)
+
+		log.Printf("evicting least-recently-used session %s (user %s)", victim.sessionID, victim.userID)
+		if err := s.Service.Delete(ctx, &session.DeleteRequest{
+			AppName:   victim.appName,
+			UserID:    victim.userID,
+			SessionID: victim.sessionID,
+		}); err != nil {
+			log.Printf("evicting session %s: %v", victim.sessionID, err)
+		}
+	}
+}
+
+func keyOf(sess session.Session) sessionKey {
+	return sessionKey{appName: sess.AppName(), userID: sess.UserID(), sessionID: sess.ID()}
+}
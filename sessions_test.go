@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/adk/session"
+)
+
+func TestLRUSessionServiceEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	s := newLRUSessionService(session.InMemoryService(), 2)
+	create := func(id string) {
+		t.Helper()
+		if _, err := s.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user", SessionID: id}); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+	exists := func(id string) bool {
+		_, err := s.Service.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: id})
+		return err == nil
+	}
+
+	create("a")
+	create("b")
+	// Using a makes b the least recently used.
+	if _, err := s.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: "a"}); err != nil {
+		t.Fatalf("Get(a): %v", err)
+	}
+	create("c")
+
+	for id, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if got := exists(id); got != want {
+			t.Errorf("session %s exists = %v, want %v", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// maxStopSequences is the most stop sequences Gemini accepts.
+const maxStopSequences = 5
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ", ") }
+
+func (l *stringList) Set(v string) error {
+	if strings.TrimSpace(v) == "" {
+		return errors.New("must not be empty")
+	}
+	*l = append(*l, v)
+	return nil
+}
+
+// listFlag defines a repeatable string flag.
+func listFlag(name, usage string) *stringList {
+	l := new(stringList)
+	flag.Var(l, name, usage)
+	return l
+}
+
+// generateContentConfig returns the generation config for an agent, or nil
+// when nothing is configured.
+func generateContentConfig() *genai.GenerateContentConfig {
+	if len(*stopSequences) == 0 {
+		return nil
+	}
+	return &genai.GenerateContentConfig{StopSequences: append([]string(nil), *stopSequences...)}
+}
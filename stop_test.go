@@ -0,0 +1,48 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/adk/agent/llmagent"
+)
+
+func TestStopSequencesReachTheModel(t *testing.T) {
+	old := *stopSequences
+	t.Cleanup(func() { *stopSequences = old })
+	*stopSequences = nil
+	for _, s := range []string{"END", "###"} {
+		if err := stopSequences.Set(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := newMockModel()
+	a, err := llmagent.New(llmagent.Config{
+		Name:                  "Coordinator",
+		Model:                 m,
+		GenerateContentConfig: generateContentConfig(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runTurns(t, a, "hello")
+	if len(m.requests) == 0 {
+		t.Fatal("the model was not called")
+	}
+	if got := m.requests[0].Config.StopSequences; !slices.Equal(got, []string{"END", "###"}) {
+		t.Errorf("stop sequences = %q, want [END ###]", got)
+	}
+}
+
+func TestGenerateContentConfigWithoutStopSequences(t *testing.T) {
+	old := *stopSequences
+	t.Cleanup(func() { *stopSequences = old })
+	*stopSequences = nil
+	if got := generateContentConfig(); got != nil {
+		t.Errorf("generateContentConfig() = %+v, want nil", got)
+	}
+	if err := stopSequences.Set("  "); err == nil {
+		t.Error("an empty stop sequence was accepted")
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/adk/session"
+)
+
+// streamPrinter prints a streamed response as its partial events arrive.
+// Events without text, which Gemini often sends before the content, are
+// swallowed; the first text starts on a new line after the response
+// indicator, and the aggregated event that follows the partials is not
+// printed again.
+type streamPrinter struct {
+	w       io.Writer
+	started bool
+}
+
+func newStreamPrinter(w io.Writer) *streamPrinter {
+	return &streamPrinter{w: w}
+}
+
+// eventText returns the non-thought text of the event's parts.
+func eventText(event *session.Event) string {
+	if event.Content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range event.Content.Parts {
+		if !part.Thought {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
+
+func (p *streamPrinter) print(event *session.Event) {
+	text := eventText(event)
+	if !event.Partial {
+		if p.started {
+			// The aggregate of the partials already printed.
+			fmt.Fprintln(p.w)
+			p.started = false
+		} else if text != "" {
+			fmt.Fprintf(p.w, "Agent Response: %s\n", formatResponse(text, *outputFormat))
+		}
+		return
+	}
+	if text == "" {
+		return
+	}
+	if !p.started {
+		fmt.Fprint(p.w, "Agent Response: ")
+		p.started = true
+	}
+	// Markdown is stripped chunk by chunk, so with -output plain a marker
+	// split across chunks may survive.
+	fmt.Fprint(p.w, formatResponse(text, *outputFormat))
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/genai"
+)
+
+func textEvent(text string, partial bool) *session.Event {
+	event := session.NewEvent("invocation")
+	event.Content = genai.NewContentFromText(text, genai.RoleModel)
+	event.Partial = partial
+	return event
+}
+
+func TestStreamPrinter(t *testing.T) {
+	var buf bytes.Buffer
+	p := newStreamPrinter(&buf)
+	for _, event := range []*session.Event{
+		textEvent("", true),
+		textEvent("Your flight ", true),
+		textEvent("is booked.", true),
+		textEvent("Your flight is booked.", false),
+		textEvent("Anything else?", false),
+	} {
+		p.print(event)
+	}
+
+	want := "Agent Response: Your flight is booked.\nAgent Response: Anything else?\n"
+	if got := buf.String(); got != want {
+		t.Errorf("printed %q, want %q", got, want)
+	}
+}
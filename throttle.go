@@ -0,0 +1,74 @@
+package main
+
+import (
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/tool"
+)
+
+// throttledErrorCodes are the error_code values of tool results that were
+// rejected by a rate limit or quota.
+var throttledErrorCodes = map[string]bool{
+	"RateLimited":   true,
+	"QuotaExceeded": true,
+}
+
+// retryAfterKey is the result field telling the model how long to wait
+// before calling a throttled tool again.
+const retryAfterKey = "retry_after_seconds"
+
+// defaultRetryAfterSeconds is the wait suggested when a throttled result
+// does not say how long to wait.
+const defaultRetryAfterSeconds = 30
+
+// throttlingGuidance is given to every agent so that throttled tools are
+// retried rather than abandoned.
+const throttlingGuidance = "If a tool result has a retry_after_seconds field, the tool was rate limited: tell the user you will try again after that many seconds, then retry the call instead of giving up."
+
+// throttled reports whether a tool result was rejected by a rate limit.
+func throttled(result map[string]any) bool {
+	if result["status"] != "error" {
+		return false
+	}
+	code, _ := result["error_code"].(string)
+	return throttledErrorCodes[code]
+}
+
+// retryAfterSeconds returns the wait a throttled result asks for, or zero.
+func retryAfterSeconds(result map[string]any) float64 {
+	switch v := result[retryAfterKey].(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// throttleHint is an after-tool callback that makes sure every throttled
+// result carries retry_after_seconds.
+func throttleHint(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error) {
+	if err != nil || !throttled(result) || retryAfterSeconds(result) > 0 {
+		return nil, nil
+	}
+	hinted := make(map[string]any, len(result)+1)
+	for k, v := range result {
+		hinted[k] = v
+	}
+	hinted[retryAfterKey] = defaultRetryAfterSeconds
+	return hinted, nil
+}
+
+// withThrottlingGuidance returns a global instruction provider that adds
+// throttlingGuidance to the instruction of next, which may be nil.
+func withThrottlingGuidance(next func(agent.ReadonlyContext) (string, error)) func(agent.ReadonlyContext) (string, error) {
+	return func(ctx agent.ReadonlyContext) (string, error) {
+		if next == nil {
+			return throttlingGuidance, nil
+		}
+		instruction, err := next(ctx)
+		if err != nil {
+			return "", err
+		}
+		return throttlingGuidance + " " + instruction, nil
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestThrottleHintAddsRetryAfter(t *testing.T) {
+	c := newTestContext(t)
+	result := map[string]any{"status": "error", "error_code": "RateLimited", "error_message": "Too many requests."}
+	hinted, err := throttleHint(c, nil, nil, result, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := retryAfterSeconds(hinted); got != defaultRetryAfterSeconds {
+		t.Errorf("retry_after_seconds = %v, want %d", got, defaultRetryAfterSeconds)
+	}
+	if hinted["error_message"] != "Too many requests." {
+		t.Errorf("hinted result lost the error message: %v", hinted)
+	}
+	if _, ok := result[retryAfterKey]; ok {
+		t.Error("the original result was modified")
+	}
+
+	// Results that already say how long to wait, and other errors, are
+	// left alone.
+	for _, r := range []map[string]any{
+		{"status": "error", "error_code": "QuotaExceeded", retryAfterKey: 120},
+		{"status": "error", "error_code": "InvalidDate"},
+		{"status": "success"},
+	} {
+		if got, _ := throttleHint(c, nil, nil, r, nil); got != nil {
+			t.Errorf("throttleHint(%v) = %v, want nil", r, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/session"
+	"google.golang.org/genai"
+)
+
+// titleMetadataKey is the session metadata key holding the session title.
+const titleMetadataKey = "title"
+
+// maxTitleWords is how many words of the first turn an untitled
+// destination-less session is named after.
+const maxTitleWords = 6
+
+// sessionTitle derives a short title from a user turn: the first known city
+// it mentions, or else its opening words.
+func sessionTitle(text string) string {
+	lower := strings.ToLower(text)
+	first, city := -1, ""
+	for c := range cityCountries {
+		i := wordIndex(lower, c)
+		// Prefer the earliest mention, and the longer name on a tie.
+		if i >= 0 && (first < 0 || i < first || i == first && len(c) > len(city)) {
+			first, city = i, c
+		}
+	}
+	if city != "" {
+		words := strings.Fields(city)
+		for i, w := range words {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+		return "Trip to " + strings.Join(words, " ")
+	}
+	words := strings.Fields(text)
+	if len(words) > maxTitleWords {
+		return strings.Join(words[:maxTitleWords], " ") + "…"
+	}
+	return strings.Join(words, " ")
+}
+
+// wordIndex returns the index of the first occurrence of word in s that is
+// not part of a longer word, or -1.
+func wordIndex(s, word string) int {
+	for offset := 0; ; {
+		i := strings.Index(s[offset:], word)
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		end := i + len(word)
+		if (i == 0 || !isLetter(s[i-1])) && (end == len(s) || !isLetter(s[end])) {
+			return i
+		}
+		offset = i + 1
+	}
+}
+
+func isLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// titleSession is a before-agent callback that titles an untitled session
+// after the turn's user input and stores the title in the session
+// metadata.
+func titleSession(ctx agent.CallbackContext) (*genai.Content, error) {
+	metadata := sessionMetadata(ctx.State())
+	if metadata[titleMetadataKey] != "" {
+		return nil, nil
+	}
+	var sb strings.Builder
+	if content := ctx.UserContent(); content != nil {
+		for _, part := range content.Parts {
+			sb.WriteString(part.Text)
+			sb.WriteString(" ")
+		}
+	}
+	title := sessionTitle(sb.String())
+	if title == "" {
+		return nil, nil
+	}
+	metadata[titleMetadataKey] = title
+	if err := ctx.State().Set(metadataStateKey, metadata); err != nil {
+		return nil, fmt.Errorf("storing session title: %w", err)
+	}
+	return nil, nil
+}
+
+// printSessions prints the user's sessions with their titles.
+func printSessions(ctx context.Context, service session.Service, appName, userID string) error {
+	resp, err := service.List(ctx, &session.ListRequest{AppName: appName, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	sessions := resp.Sessions
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID() < sessions[j].ID() })
+	fmt.Printf("\nSessions of %s:\n", userID)
+	for _, s := range sessions {
+		title := sessionMetadata(s.State())[titleMetadataKey]
+		if title == "" {
+			title = "(untitled)"
+		}
+		fmt.Printf("  %s  %s\n", s.ID(), title)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/genai"
+)
+
+func TestSessionTitle(t *testing.T) {
+	tests := []struct{ text, want string }{
+		{"i want to visit new york and then london", "Trip to New York"},
+		{"book me a hotel in Paris", "Trip to Paris"},
+		{"what can you help me with today please", "what can you help me with…"},
+	}
+	for _, tt := range tests {
+		if got := sessionTitle(tt.text); got != tt.want {
+			t.Errorf("sessionTitle(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSessionTitledAfterFirstDestination(t *testing.T) {
+	getTool, err := functiontool.New(functiontool.Config{Name: "getSessionMetadata", Description: "get"}, getSessionMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "Coordinator",
+		Model: newMockModel(
+			genai.NewContentFromText("Tokyo it is.", genai.RoleModel),
+			calls("getSessionMetadata", map[string]any{}),
+		),
+		Tools:                []tool.Tool{getTool},
+		BeforeAgentCallbacks: []agent.BeforeAgentCallback{titleSession},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := functionResponses(runTurns(t, a, "I'd like to go to Tokyo", "actually add Paris too"), "getSessionMetadata")
+	if len(responses) != 1 {
+		t.Fatalf("got %d getSessionMetadata responses, want 1", len(responses))
+	}
+	metadata, _ := responses[0]["metadata"].(map[string]any)
+	if got := metadata[titleMetadataKey]; got != "Trip to Tokyo" {
+		t.Errorf("title = %v, want Trip to Tokyo", got)
+	}
+}
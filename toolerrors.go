@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/genai"
+)
+
+// toolError is a tool call that returned an error result.
+type toolError struct {
+	Tool    string
+	Message string
+}
+
+// toolErrors returns the error results among the function responses in
+// content. A result is an error when its status is "error", or "partial"
+// for a batch tool where some items failed, following the result shape
+// every tool in this program uses.
+func toolErrors(content *genai.Content) []toolError {
+	if content == nil {
+		return nil
+	}
+	var errs []toolError
+	for _, part := range content.Parts {
+		resp := part.FunctionResponse
+		if resp == nil {
+			continue
+		}
+		if status := resp.Response["status"]; status != "error" && status != "partial" {
+			continue
+		}
+		message, _ := resp.Response["error_message"].(string)
+		if message == "" {
+			message = "no error message given"
+		}
+		errs = append(errs, toolError{Tool: resp.Name, Message: message})
+	}
+	return errs
+}
+
+func (e toolError) String() string {
+	return fmt.Sprintf("%s: %s", e.Tool, e.Message)
+}
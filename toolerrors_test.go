@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestToolErrors(t *testing.T) {
+	content := &genai.Content{Role: "user", Parts: []*genai.Part{
+		genai.NewPartFromFunctionResponse("bookFlight", map[string]any{"status": "error", "error_message": "No flights on that date."}),
+		genai.NewPartFromFunctionResponse("bookHotel", map[string]any{"status": "success"}),
+		genai.NewPartFromFunctionResponse("bookBundle", map[string]any{"status": "partial"}),
+	}}
+	got := toolErrors(content)
+	want := []toolError{
+		{Tool: "bookFlight", Message: "No flights on that date."},
+		{Tool: "bookBundle", Message: "no error message given"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("toolErrors = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("toolErrors[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if errs := toolErrors(nil); errs != nil {
+		t.Errorf("toolErrors(nil) = %v, want nil", errs)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+type travelLeg struct {
+	Confirmation string `json:"confirmation"`
+	Route        string `json:"route"`
+	Minutes      int    `json:"flight_minutes"`
+	// LayoverMinutes is the connection time before the next leg.
+	LayoverMinutes int `json:"layover_minutes,omitempty"`
+}
+
+type totalTravelTimeArg struct{}
+type totalTravelTimeResult struct {
+	Status       string      `json:"status"`
+	Legs         []travelLeg `json:"legs,omitempty"`
+	TotalMinutes int         `json:"total_minutes"`
+	Report       string      `json:"report,omitempty"`
+}
+
+// activeFlights returns the session's flights that are not cancelled, in
+// booking order.
+func activeFlights(sessionID string) []booking {
+	var flights []booking
+	for _, b := range bookings.forSession(sessionID) {
+		if b.Kind == kindFlight && !b.Cancelled {
+			flights = append(flights, b)
+		}
+	}
+	return flights
+}
+
+// connectionWindow is the longest gap between landing and the next
+// departure for the two flights to count as one journey.
+const connectionWindow = 24 * time.Hour
+
+// isConnection reports whether next continues the journey from prev: it
+// leaves from where prev lands, within connectionWindow of its arrival,
+// and does not fly straight back to where prev started.
+func isConnection(prev, next booking) bool {
+	if !strings.EqualFold(strings.TrimSpace(prev.Destination), strings.TrimSpace(next.Origin)) ||
+		strings.EqualFold(strings.TrimSpace(prev.Origin), strings.TrimSpace(next.Destination)) {
+		return false
+	}
+	departed, err := scheduledDeparture(prev)
+	if err != nil {
+		return false
+	}
+	departs, err := scheduledDeparture(next)
+	if err != nil {
+		return false
+	}
+	gap := departs.Sub(departed.Add(flightDuration(prev.Origin, prev.Destination)))
+	return gap >= 0 && gap <= connectionWindow
+}
+
+func totalTravelTime(c tool.Context, arg totalTravelTimeArg) totalTravelTimeResult {
+	flights := activeFlights(c.SessionID())
+	if len(flights) == 0 {
+		return totalTravelTimeResult{Status: "success", Report: "There are no flights booked in this session."}
+	}
+
+	var legs []travelLeg
+	var total time.Duration
+	for i, f := range flights {
+		flight := flightDuration(f.Origin, f.Destination)
+		total += flight
+		leg := travelLeg{
+			Confirmation: f.Confirmation,
+			Route:        fmt.Sprintf("%s -> %s", f.Origin, f.Destination),
+			Minutes:      int(flight.Minutes()),
+		}
+		if i+1 < len(flights) && isConnection(f, flights[i+1]) {
+			layover := connectionTime(f.Destination)
+			total += layover
+			leg.LayoverMinutes = int(layover.Minutes())
+		}
+		legs = append(legs, leg)
+	}
+
+	return totalTravelTimeResult{
+		Status:       "success",
+		Legs:         legs,
+		TotalMinutes: int(total.Minutes()),
+		Report:       fmt.Sprintf("Total travel time across %d flight(s): %dh%02dm.", len(legs), int(total.Hours()), int(total.Minutes())%60),
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestTotalTravelTime(t *testing.T) {
+	c := newTestContext(t)
+	// London to Tokyo via New York, then home a week later.
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "New York", Date: "2025-11-14"})
+	bookFlight(c, bookFlightArg{Origin: "New York", Destination: "Tokyo", Date: "2025-11-15"})
+	bookFlight(c, bookFlightArg{Origin: "Tokyo", Destination: "New York", Date: "2025-11-22"})
+
+	got := totalTravelTime(c, totalTravelTimeArg{})
+	if len(got.Legs) != 3 {
+		t.Fatalf("got %d legs, want 3", len(got.Legs))
+	}
+	// 480 + 840 + 840 minutes flying, with only the New York connection
+	// counted as a layover.
+	if want := 480 + 840 + 840 + 150; got.TotalMinutes != want {
+		t.Errorf("TotalMinutes = %d, want %d", got.TotalMinutes, want)
+	}
+	for i, want := range []int{150, 0, 0} {
+		if got.Legs[i].LayoverMinutes != want {
+			t.Errorf("leg %d layover = %d, want %d", i, got.Legs[i].LayoverMinutes, want)
+		}
+	}
+}
+
+func TestIsConnection(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next booking
+		want       bool
+	}{
+		{"same day onward", booking{Origin: "London", Destination: "Paris", Date: "2025-11-14"}, booking{Origin: "Paris", Destination: "Dubai", Date: "2025-11-14"}, true},
+		{"next day onward", booking{Origin: "London", Destination: "New York", Date: "2025-11-14"}, booking{Origin: "New York", Destination: "Tokyo", Date: "2025-11-15"}, true},
+		{"a week later", booking{Origin: "London", Destination: "Paris", Date: "2025-11-14"}, booking{Origin: "Paris", Destination: "Dubai", Date: "2025-11-21"}, false},
+		{"return leg", booking{Origin: "London", Destination: "Paris", Date: "2025-11-14"}, booking{Origin: "Paris", Destination: "London", Date: "2025-11-14"}, false},
+		{"different city", booking{Origin: "London", Destination: "Paris", Date: "2025-11-14"}, booking{Origin: "Dubai", Destination: "Tokyo", Date: "2025-11-14"}, false},
+	}
+	for _, tt := range tests {
+		if got := isConnection(tt.prev, tt.next); got != tt.want {
+			t.Errorf("%s: isConnection = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
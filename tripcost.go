@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// tripPlan is a round trip with a hotel stay at the destination.
+type tripPlan struct {
+	Origin      string
+	Destination string
+	FareClass   string
+	Depart      string
+	Return      string
+}
+
+// tripCostBreakdown is the estimated cost of a tripPlan.
+type tripCostBreakdown struct {
+	Outbound float64 `json:"outbound_flight"`
+	Return   float64 `json:"return_flight"`
+	Hotel    float64 `json:"hotel"`
+	Nights   int     `json:"nights"`
+	Total    float64 `json:"total"`
+}
+
+// estimateTripCost prices the flights and every hotel night of a trip using
+// the same rates the booking tools charge.
+func estimateTripCost(p tripPlan) (tripCostBreakdown, error) {
+	depart, err := time.Parse(dateLayout, strings.TrimSpace(p.Depart))
+	if err != nil {
+		return tripCostBreakdown{}, fmt.Errorf("invalid departure date %q, expected YYYY-MM-DD", p.Depart)
+	}
+	ret, err := time.Parse(dateLayout, strings.TrimSpace(p.Return))
+	if err != nil {
+		return tripCostBreakdown{}, fmt.Errorf("invalid return date %q, expected YYYY-MM-DD", p.Return)
+	}
+	if !ret.After(depart) {
+		return tripCostBreakdown{}, fmt.Errorf("return date %s must be after departure date %s", p.Return, p.Depart)
+	}
+	fareClass := p.FareClass
+	if fareClass == "" {
+		fareClass = "economy"
+	}
+
+	c := tripCostBreakdown{
+		Outbound: flightPrice(p.Origin, p.Destination, fareClass, p.Depart),
+		Return:   flightPrice(p.Destination, p.Origin, fareClass, p.Return),
+	}
+	for night := depart; night.Before(ret); night = night.AddDate(0, 0, 1) {
+		c.Hotel += hotelPrice(p.Destination, night.Format(dateLayout))
+		c.Nights++
+	}
+	c.Total = c.Outbound + c.Return + c.Hotel
+	return c, nil
+}
+
+type tripDates struct {
+	Depart string `json:"depart" jsonschema:"the departure date, YYYY-MM-DD"`
+	Return string `json:"return" jsonschema:"the return date, YYYY-MM-DD"`
+}
+
+type compareTripCostByDateArg struct {
+	Origin      string    `json:"origin" jsonschema:"the origin city of the trip"`
+	Destination string    `json:"destination" jsonschema:"the destination city of the trip"`
+	FareClass   string    `json:"fare_class,omitempty" jsonschema:"the fare class; defaults to economy"`
+	First       tripDates `json:"first" jsonschema:"the first candidate dates"`
+	Second      tripDates `json:"second" jsonschema:"the second candidate dates"`
+}
+type compareTripCostByDateResult struct {
+	Status       string            `json:"status"`
+	First        tripCostBreakdown `json:"first"`
+	Second       tripCostBreakdown `json:"second"`
+	Cheaper      string            `json:"cheaper,omitempty"`
+	Savings      float64           `json:"savings"`
+	Report       string            `json:"report,omitempty"`
+	ErrorMessage string            `json:"error_message,omitempty"`
+}
+
+func compareTripCostByDate(c tool.Context, arg compareTripCostByDateArg) compareTripCostByDateResult {
+	plan := tripPlan{Origin: arg.Origin, Destination: arg.Destination, FareClass: strings.ToLower(strings.TrimSpace(arg.FareClass))}
+
+	plan.Depart, plan.Return = arg.First.Depart, arg.First.Return
+	first, err := estimateTripCost(plan)
+	if err != nil {
+		return compareTripCostByDateResult{Status: "error", ErrorMessage: fmt.Sprintf("First date set: %v.", err)}
+	}
+	plan.Depart, plan.Return = arg.Second.Depart, arg.Second.Return
+	second, err := estimateTripCost(plan)
+	if err != nil {
+		return compareTripCostByDateResult{Status: "error", ErrorMessage: fmt.Sprintf("Second date set: %v.", err)}
+	}
+
+	result := compareTripCostByDateResult{Status: "success", First: first, Second: second}
+	switch {
+	case first.Total < second.Total:
+		result.Cheaper = "first"
+		result.Savings = second.Total - first.Total
+		result.Report = fmt.Sprintf("%s to %s is %.2f cheaper than %s to %s.", arg.First.Depart, arg.First.Return, result.Savings, arg.Second.Depart, arg.Second.Return)
+	case second.Total < first.Total:
+		result.Cheaper = "second"
+		result.Savings = first.Total - second.Total
+		result.Report = fmt.Sprintf("%s to %s is %.2f cheaper than %s to %s.", arg.Second.Depart, arg.Second.Return, result.Savings, arg.First.Depart, arg.First.Return)
+	default:
+		result.Report = fmt.Sprintf("Both date sets cost %.2f.", first.Total)
+	}
+	return result
+}
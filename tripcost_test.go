@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestCompareTripCostByDate(t *testing.T) {
+	c := newTestContext(t)
+	// A week in peak August against the same week in off-peak November.
+	got := compareTripCostByDate(c, compareTripCostByDateArg{
+		Origin:      "London",
+		Destination: "New York",
+		First:       tripDates{Depart: "2025-08-04", Return: "2025-08-11"},
+		Second:      tripDates{Depart: "2025-11-03", Return: "2025-11-10"},
+	})
+	if got.Status != "success" {
+		t.Fatalf("Status = %q (%s)", got.Status, got.ErrorMessage)
+	}
+	if got.Cheaper != "second" {
+		t.Errorf("Cheaper = %q, want second", got.Cheaper)
+	}
+	if want := got.First.Total - got.Second.Total; got.Savings != want || want <= 0 {
+		t.Errorf("Savings = %.2f, want %.2f", got.Savings, want)
+	}
+}
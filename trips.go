@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+type createTripReferenceArg struct{}
+type createTripReferenceResult struct {
+	Status        string   `json:"status"`
+	TripReference string   `json:"trip_reference,omitempty"`
+	Confirmations []string `json:"confirmations,omitempty"`
+	Report        string   `json:"report,omitempty"`
+	ErrorMessage  string   `json:"error_message,omitempty"`
+}
+
+func createTripReference(c tool.Context, arg createTripReferenceArg) createTripReferenceResult {
+	ref, codes := bookings.groupTrip(c.SessionID())
+	if ref == "" {
+		return createTripReferenceResult{Status: "error", ErrorMessage: "There are no ungrouped bookings to put under a trip reference."}
+	}
+	return createTripReferenceResult{
+		Status:        "success",
+		TripReference: ref,
+		Confirmations: codes,
+		Report:        fmt.Sprintf("Grouped %s under trip reference %s.", strings.Join(codes, ", "), ref),
+	}
+}
+
+type cancelTripReferenceArg struct {
+	TripReference string `json:"trip_reference" jsonschema:"the trip reference to cancel"`
+}
+type cancelTripReferenceResult struct {
+	Status        string        `json:"status"`
+	Confirmations []string      `json:"cancelled,omitempty"`
+	Items         []itemOutcome `json:"items,omitempty"`
+	Report        string        `json:"report,omitempty"`
+	ErrorMessage  string        `json:"error_message,omitempty"`
+}
+
+// cancelTripReference cancels every booking under a trip reference,
+// reporting each booking separately.
+func cancelTripReference(c tool.Context, arg cancelTripReferenceArg) cancelTripReferenceResult {
+	grouped, ok := bookings.trip(c.SessionID(), arg.TripReference)
+	if !ok {
+		return cancelTripReferenceResult{Status: "error", ErrorMessage: fmt.Sprintf("Unknown trip reference %s.", arg.TripReference)}
+	}
+	var cancelled []string
+	var items []itemOutcome
+	for _, b := range grouped {
+		switch {
+		case b.Cancelled:
+			items = append(items, itemSucceeded(b.Confirmation, "already cancelled"))
+		case b.Kind == kindFlight && departed(b):
+			items = append(items, itemFailed(b.Confirmation, "Flight %s already departed on %s and can no longer be cancelled.", b.Confirmation, b.Date))
+		case !bookings.update(c.SessionID(), b.Confirmation, func(b *booking) { b.Cancelled = true }):
+			items = append(items, itemFailed(b.Confirmation, "%s could not be found.", b.Confirmation))
+		default:
+			items = append(items, itemSucceeded(b.Confirmation, "cancelled"))
+			cancelled = append(cancelled, b.Confirmation)
+		}
+	}
+
+	result := cancelTripReferenceResult{Status: batchStatus(items), Confirmations: cancelled, Items: items}
+	failed := failedItems(items)
+	switch {
+	case len(failed) > 0:
+		result.ErrorMessage = fmt.Sprintf("Could not cancel %s under %s.", strings.Join(failed, ", "), arg.TripReference)
+		if len(cancelled) > 0 {
+			result.Report = fmt.Sprintf("Cancelled %s.", strings.Join(cancelled, ", "))
+		}
+	case len(cancelled) == 0:
+		result.Report = fmt.Sprintf("All bookings under %s were already cancelled.", arg.TripReference)
+	default:
+		result.Report = fmt.Sprintf("Cancelled %s under trip reference %s.", strings.Join(cancelled, ", "), arg.TripReference)
+	}
+	return result
+}
+
+// departed reports whether flight b has left.
+func departed(b booking) bool {
+	departure, err := scheduledDeparture(b)
+	return err == nil && departure.Before(now())
+}
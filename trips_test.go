@@ -0,0 +1,78 @@
+package main
+
+import "testing"
+
+func TestCancelTripReferenceCancelsGroupedBookings(t *testing.T) {
+	setNow(t, localTime(t, "2025-11-01 09:00"))
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Dubai", Date: "2025-11-14"})
+	bookHotel(c, bookHotelArg{Location: "Dubai", Date: "2025-11-14"})
+	created := createTripReference(c, createTripReferenceArg{})
+	if created.Status != "success" || len(created.Confirmations) != 2 {
+		t.Fatalf("createTripReference: got %+v, want both bookings grouped", created)
+	}
+
+	got := cancelTripReference(c, cancelTripReferenceArg{TripReference: created.TripReference})
+	if got.Status != "success" || len(got.Confirmations) != 2 {
+		t.Fatalf("cancelTripReference: got %+v, want both bookings cancelled", got)
+	}
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if !b.Cancelled {
+			t.Errorf("%s was not cancelled", b.Confirmation)
+		}
+	}
+}
+
+func TestCancelTripReferenceReportsEachBooking(t *testing.T) {
+	setNow(t, localTime(t, "2025-11-01 09:00"))
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	flight := confirmationOf(t, c)
+	bookHotel(c, bookHotelArg{Location: "Edinburgh", Date: "2025-11-14"})
+	hotel := confirmationOf(t, c)
+	created := createTripReference(c, createTripReferenceArg{})
+	bookings.update(c.SessionID(), hotel, func(b *booking) { b.Cancelled = true })
+	// A checked-in flight is cancelled like any other.
+	bookings.update(c.SessionID(), flight, func(b *booking) { b.CheckedIn = true })
+
+	got := cancelTripReference(c, cancelTripReferenceArg{TripReference: created.TripReference})
+	if got.Status != "success" || len(got.Items) != 2 {
+		t.Fatalf("cancelTripReference: got %+v, want an outcome for each booking", got)
+	}
+	details := map[string]string{}
+	for _, item := range got.Items {
+		details[item.Item] = item.Detail
+	}
+	if details[flight] != "cancelled" || details[hotel] != "already cancelled" {
+		t.Errorf("outcomes = %v, want %s cancelled and %s already cancelled", details, flight, hotel)
+	}
+}
+
+func TestCancelTripReferenceKeepsDepartedFlight(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-14"})
+	outbound := confirmationOf(t, c)
+	bookHotel(c, bookHotelArg{Location: "Edinburgh", Date: "2025-11-14"})
+	hotel := confirmationOf(t, c)
+	bookFlight(c, bookFlightArg{Origin: "Edinburgh", Destination: "London", Date: "2025-11-16"})
+	inbound := confirmationOf(t, c)
+	created := createTripReference(c, createTripReferenceArg{})
+
+	setNow(t, localTime(t, "2025-11-15 12:00"))
+	got := cancelTripReference(c, cancelTripReferenceArg{TripReference: created.TripReference})
+	if got.Status != "partial" {
+		t.Fatalf("cancelTripReference: got %+v, want a partial cancellation", got)
+	}
+	status := map[string]string{}
+	for _, item := range got.Items {
+		status[item.Item] = item.Status
+	}
+	if status[outbound] != "error" || status[hotel] != "success" || status[inbound] != "success" {
+		t.Errorf("outcomes = %v, want only the departed flight %s to fail", status, outbound)
+	}
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if b.Cancelled != (b.Confirmation != outbound) {
+			t.Errorf("%s cancelled = %t", b.Confirmation, b.Cancelled)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+const (
+	// unknownToolName is the tool that calls to unregistered tools are
+	// redirected to.
+	unknownToolName = "reportUnknownTool"
+	// transferToolName is the tool ADK adds for delegating to other agents.
+	transferToolName = "transfer_to_agent"
+)
+
+// unknownToolGuard redirects calls to tools that an agent does not have to
+// the reportUnknownTool tool. The ADK flow fails the whole turn on an
+// unknown tool; a redirected call instead returns an error result listing
+// the available tools, so the model can pick a valid one.
+type unknownToolGuard struct {
+	mu    sync.Mutex
+	tools map[string][]string // agent name -> tool names
+}
+
+func newUnknownToolGuard() *unknownToolGuard {
+	return &unknownToolGuard{tools: make(map[string][]string)}
+}
+
+// register records the tools available to the named agent.
+func (g *unknownToolGuard) register(agentName string, tools []tool.Tool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	names := []string{transferToolName}
+	for _, t := range tools {
+		if t.Name() != unknownToolName {
+			names = append(names, t.Name())
+		}
+	}
+	sort.Strings(names)
+	g.tools[agentName] = names
+}
+
+func (g *unknownToolGuard) available(agentName string) []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.tools[agentName]
+}
+
+func (g *unknownToolGuard) afterModel(ctx agent.CallbackContext, resp *model.LLMResponse, respErr error) (*model.LLMResponse, error) {
+	if respErr != nil || resp == nil || resp.Content == nil {
+		return nil, nil
+	}
+	available := g.available(ctx.AgentName())
+	for _, part := range resp.Content.Parts {
+		call := part.FunctionCall
+		if call == nil || call.Name == unknownToolName {
+			continue
+		}
+		idx := sort.SearchStrings(available, call.Name)
+		if idx < len(available) && available[idx] == call.Name {
+			continue
+		}
+		log.Printf("agent %s called unknown tool %q, redirecting to %s", ctx.AgentName(), call.Name, unknownToolName)
+		call.Args = map[string]any{"requested_tool": call.Name}
+		call.Name = unknownToolName
+	}
+	return nil, nil
+}
+
+type reportUnknownToolArg struct {
+	RequestedTool string `json:"requested_tool" jsonschema:"the tool name that was requested"`
+}
+type reportUnknownToolResult struct {
+	Status         string   `json:"status"`
+	AvailableTools []string `json:"available_tools"`
+	ErrorMessage   string   `json:"error_message"`
+}
+
+func (g *unknownToolGuard) reportUnknownTool(c tool.Context, arg reportUnknownToolArg) reportUnknownToolResult {
+	available := g.available(c.AgentName())
+	return reportUnknownToolResult{
+		Status:         "error",
+		AvailableTools: available,
+		ErrorMessage: fmt.Sprintf("There is no tool named %q. Choose one of: %s.",
+			arg.RequestedTool, strings.Join(available, ", ")),
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestUnknownToolCallRecovers(t *testing.T) {
+	hotelTool, err := functiontool.New(functiontool.Config{Name: "bookHotel", Description: "book a hotel"}, bookHotel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guard := newUnknownToolGuard()
+	unknownTool, err := functiontool.New(functiontool.Config{Name: unknownToolName, Description: "unknown tool"}, guard.reportUnknownTool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tools := []tool.Tool{hotelTool, unknownTool}
+	guard.register("Booker", tools)
+	a, err := llmagent.New(llmagent.Config{
+		Name:                "Booker",
+		Model:               newMockModel(calls("bookTrain", map[string]any{"route": "London-Paris"})),
+		Tools:               tools,
+		AfterModelCallbacks: []llmagent.AfterModelCallback{guard.afterModel},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// runTurns fails the test if the turn errors.
+	responses := functionResponses(runTurns(t, a, "book me a train"), unknownToolName)
+	if len(responses) != 1 {
+		t.Fatalf("got %d %s responses, want 1", len(responses), unknownToolName)
+	}
+	if responses[0]["status"] != "error" {
+		t.Errorf("status = %v, want error", responses[0]["status"])
+	}
+	var available []string
+	for _, name := range responses[0]["available_tools"].([]any) {
+		available = append(available, name.(string))
+	}
+	if want := []string{"bookHotel", transferToolName}; !slices.Equal(available, want) {
+		t.Errorf("available_tools = %v, want %v", available, want)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"google.golang.org/adk/tool"
+)
+
+// upgradeValue is a canned comfort score for each kind of upgrade, used to
+// rank upgrades by what they are worth against what they cost.
+var upgradeValue = map[string]float64{
+	"premium economy": 2,
+	"business":        5,
+	"hotel":           1,
+}
+
+// hotelUpgradeFactor is the share of the nightly rate charged to move up to
+// a superior room.
+const hotelUpgradeFactor = 0.4
+
+type upgradeSuggestion struct {
+	Confirmation string  `json:"confirmation"`
+	Upgrade      string  `json:"upgrade"`
+	Cost         float64 `json:"cost"`
+	Value        float64 `json:"value"`
+}
+
+type suggestUpgradesArg struct {
+	RemainingBudget float64 `json:"remaining_budget" jsonschema:"how much more the user is willing to spend"`
+}
+type suggestUpgradesResult struct {
+	Status       string              `json:"status"`
+	Suggestions  []upgradeSuggestion `json:"suggestions,omitempty"`
+	Report       string              `json:"report,omitempty"`
+	ErrorMessage string              `json:"error_message,omitempty"`
+}
+
+// upgradeOptions returns every paid upgrade available for b.
+func upgradeOptions(b booking) []upgradeSuggestion {
+	switch b.Kind {
+	case kindFlight:
+		var options []upgradeSuggestion
+		for _, class := range []string{"premium economy", "business"} {
+			if fareClassMultipliers[class] <= fareClassMultipliers[b.FareClass] {
+				continue
+			}
+			cost := math.Round((flightPrice(b.Origin, b.Destination, class, b.Date)-b.Price)*100) / 100
+			options = append(options, upgradeSuggestion{
+				Confirmation: b.Confirmation,
+				Upgrade:      class + " cabin",
+				Cost:         cost,
+				Value:        upgradeValue[class],
+			})
+		}
+		return options
+	case kindHotel:
+		return []upgradeSuggestion{{
+			Confirmation: b.Confirmation,
+			Upgrade:      "superior room",
+			Cost:         math.Round(b.Price*hotelUpgradeFactor*100) / 100,
+			Value:        upgradeValue["hotel"],
+		}}
+	}
+	return nil
+}
+
+func suggestUpgrades(c tool.Context, arg suggestUpgradesArg) suggestUpgradesResult {
+	if arg.RemainingBudget < 0 {
+		return suggestUpgradesResult{Status: "error", ErrorMessage: "The remaining budget must not be negative."}
+	}
+	var fits []upgradeSuggestion
+	for _, b := range bookings.forSession(c.SessionID()) {
+		if b.Cancelled {
+			continue
+		}
+		for _, option := range upgradeOptions(b) {
+			if option.Cost <= arg.RemainingBudget {
+				fits = append(fits, option)
+			}
+		}
+	}
+	if len(fits) == 0 {
+		return suggestUpgradesResult{
+			Status: "success",
+			Report: fmt.Sprintf("No upgrades fit within the remaining budget of %.2f.", arg.RemainingBudget),
+		}
+	}
+	// Best value for money first; cheaper upgrades break ties.
+	sort.SliceStable(fits, func(i, j int) bool {
+		vi, vj := fits[i].Value/fits[i].Cost, fits[j].Value/fits[j].Cost
+		if vi != vj {
+			return vi > vj
+		}
+		return fits[i].Cost < fits[j].Cost
+	})
+	return suggestUpgradesResult{
+		Status:      "success",
+		Suggestions: fits,
+		Report:      fmt.Sprintf("%d upgrades fit within the remaining budget of %.2f, best value first.", len(fits), arg.RemainingBudget),
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestSuggestUpgradesWithinBudget(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Edinburgh", Date: "2025-11-12"})
+	b, _ := bookings.get(c.SessionID(), confirmationOf(t, c))
+	options := upgradeOptions(b)
+	if len(options) != 2 {
+		t.Fatalf("got %d upgrade options for economy, want 2", len(options))
+	}
+	premium, business := options[0], options[1]
+
+	// Enough for premium economy but not business.
+	budget := (premium.Cost + business.Cost) / 2
+	got := suggestUpgrades(c, suggestUpgradesArg{RemainingBudget: budget})
+	if len(got.Suggestions) != 1 || got.Suggestions[0].Upgrade != "premium economy cabin" {
+		t.Errorf("budget %.2f: got %+v, want only the premium economy upgrade", budget, got.Suggestions)
+	}
+	if got := suggestUpgrades(c, suggestUpgradesArg{RemainingBudget: premium.Cost - 1}); len(got.Suggestions) != 0 {
+		t.Errorf("budget below every upgrade: got %+v, want none", got.Suggestions)
+	}
+}
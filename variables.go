@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+)
+
+// variablesStateKey is the session state key holding the variables that
+// are rendered into the coordinator instruction.
+const variablesStateKey = "session_variables"
+
+// variableNamePattern matches valid session variable names, such as
+// destination_confirmed.
+var variableNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+// sessionVariables returns a copy of the variables stored in state.
+func sessionVariables(state session.ReadonlyState) map[string]string {
+	variables := make(map[string]string)
+	v, err := state.Get(variablesStateKey)
+	if err != nil {
+		return variables
+	}
+	switch m := v.(type) {
+	case map[string]string:
+		for k, v := range m {
+			variables[k] = v
+		}
+	case map[string]any:
+		for k, v := range m {
+			variables[k] = fmt.Sprint(v)
+		}
+	}
+	return variables
+}
+
+// renderVariables formats variables as a sentence for the instruction,
+// sorted by name. It returns "" when there are none.
+func renderVariables(variables map[string]string) string {
+	if len(variables) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%s", name, variables[name])
+	}
+	return "Session variables: " + strings.Join(pairs, ", ") + "."
+}
+
+// instructionWithVariables returns an instruction provider that appends
+// the session's variables to instruction, so variables set by a tool shape
+// every later model call.
+func instructionWithVariables(instruction string) func(agent.ReadonlyContext) (string, error) {
+	return func(ctx agent.ReadonlyContext) (string, error) {
+		if vars := renderVariables(sessionVariables(ctx.ReadonlyState())); vars != "" {
+			return instruction + " " + vars, nil
+		}
+		return instruction, nil
+	}
+}
+
+type setSessionVariableArg struct {
+	Name  string `json:"name" jsonschema:"the variable name: lower case letters, digits and underscores, e.g. destination_confirmed"`
+	Value string `json:"value" jsonschema:"the variable value"`
+}
+type setSessionVariableResult struct {
+	Status       string `json:"status"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func setSessionVariable(c tool.Context, arg setSessionVariableArg) setSessionVariableResult {
+	name := strings.TrimSpace(arg.Name)
+	if !variableNamePattern.MatchString(name) {
+		return setSessionVariableResult{
+			Status:       "error",
+			ErrorMessage: fmt.Sprintf("Invalid variable name %q; use lower case letters, digits and underscores, starting with a letter.", arg.Name),
+		}
+	}
+	variables := sessionVariables(c.State())
+	variables[name] = arg.Value
+	if err := c.State().Set(variablesStateKey, variables); err != nil {
+		return setSessionVariableResult{Status: "error", ErrorMessage: fmt.Sprintf("Storing variable: %v", err)}
+	}
+	return setSessionVariableResult{
+		Status: "success",
+		Report: fmt.Sprintf("Session variable %s set to %q.", name, arg.Value),
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/genai"
+)
+
+func TestSessionVariableRenderedOnNextTurn(t *testing.T) {
+	setTool, err := functiontool.New(functiontool.Config{Name: "setSessionVariable", Description: "set"}, setSessionVariable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newMockModel(
+		calls("setSessionVariable", map[string]any{"name": "destination_confirmed", "value": "true"}),
+		genai.NewContentFromText("Noted.", genai.RoleModel),
+	)
+	a, err := llmagent.New(llmagent.Config{
+		Name:                "Coordinator",
+		Model:               m,
+		InstructionProvider: instructionWithVariables("Help with travel."),
+		Tools:               []tool.Tool{setTool},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runTurns(t, a, "yes, Lisbon is confirmed", "what next?")
+	first := m.requests[0].Config.SystemInstruction
+	last := m.requests[len(m.requests)-1].Config.SystemInstruction
+	if text := instructionText(first); strings.Contains(text, "Session variables") {
+		t.Errorf("first turn instruction %q already has variables", text)
+	}
+	if text := instructionText(last); !strings.Contains(text, "Session variables: destination_confirmed=true.") {
+		t.Errorf("next turn instruction %q does not include the variable", text)
+	}
+}
+
+func TestSetSessionVariableRejectsInvalidName(t *testing.T) {
+	if got := setSessionVariable(newTestContext(t), setSessionVariableArg{Name: "Destination Confirmed", Value: "true"}); got.Status != "error" {
+		t.Errorf("got status %q, want error", got.Status)
+	}
+}
+
+func instructionText(c *genai.Content) string {
+	if c == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, p := range c.Parts {
+		b.WriteString(p.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error verbosity levels for -error-verbosity.
+const (
+	errorsFriendly = "friendly"
+	errorsDetailed = "detailed"
+	errorsDebug    = "debug"
+)
+
+// friendlyErrorMessage is all the friendly level shows of an error.
+const friendlyErrorMessage = "Sorry, something went wrong while handling your request. Please try again."
+
+// describeError renders err for the user at the given verbosity: friendly
+// hides internals, detailed shows the error message and debug shows every
+// error in the wrapped chain with its type.
+func describeError(err error, verbosity string) string {
+	switch verbosity {
+	case errorsDetailed:
+		return err.Error()
+	case errorsDebug:
+		var sb strings.Builder
+		for i, e := 0, err; e != nil; i, e = i+1, errors.Unwrap(e) {
+			if i > 0 {
+				sb.WriteString("\n  caused by: ")
+			}
+			fmt.Fprintf(&sb, "%T: %v", e, e)
+		}
+		return sb.String()
+	default:
+		return friendlyErrorMessage
+	}
+}
+
+// describeStartupError renders an error that stopped the agent from
+// starting. Those are configuration problems the user has to fix, so even
+// the friendly level names the cause; only failed turns hide internals.
+func describeStartupError(err error, verbosity string) string {
+	if verbosity == errorsDebug {
+		return describeError(err, verbosity)
+	}
+	return describeError(err, errorsDetailed)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDescribeError(t *testing.T) {
+	err := fmt.Errorf("booking flight: %w", errors.New("upstream timeout"))
+
+	if got := describeError(err, errorsFriendly); got != friendlyErrorMessage {
+		t.Errorf("friendly = %q, want %q", got, friendlyErrorMessage)
+	}
+	if got := describeError(err, errorsDetailed); got != "booking flight: upstream timeout" {
+		t.Errorf("detailed = %q, want the error message", got)
+	}
+	debug := describeError(err, errorsDebug)
+	lines := strings.Split(debug, "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "*fmt.wrapError: booking flight") ||
+		lines[1] != "  caused by: *errors.errorString: upstream timeout" {
+		t.Errorf("debug = %q, want both errors of the chain with their types", debug)
+	}
+}
+
+func TestDescribeStartupErrorNamesCause(t *testing.T) {
+	err := fmt.Errorf("creating model: %w", errors.New("API_KEY is not set"))
+	for _, verbosity := range []string{errorsFriendly, errorsDetailed, errorsDebug} {
+		if got := describeStartupError(err, verbosity); !strings.Contains(got, "API_KEY is not set") {
+			t.Errorf("%s: %q does not name the cause", verbosity, got)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/tool"
+)
+
+// visaFreeDays is the canned maximum visa-free stay in days by lower-case
+// nationality, then destination. A zero means a visa is needed for any
+// stay.
+var visaFreeDays = map[string]map[string]int{
+	"united kingdom": {
+		"france":               90,
+		"germany":              90,
+		"united states":        90,
+		"japan":                90,
+		"singapore":            30,
+		"thailand":             30,
+		"united arab emirates": 40,
+		"australia":            0,
+		"india":                0,
+	},
+	"united states": {
+		"united kingdom":       180,
+		"france":               90,
+		"germany":              90,
+		"japan":                90,
+		"singapore":            90,
+		"thailand":             30,
+		"united arab emirates": 30,
+		"australia":            0,
+		"india":                0,
+	},
+	"france": {
+		"united kingdom":       180,
+		"united states":        90,
+		"japan":                90,
+		"singapore":            90,
+		"thailand":             30,
+		"united arab emirates": 90,
+		"india":                0,
+	},
+}
+
+// sessionTripDays returns the length in days of the session's stay in
+// country (a lower-case name as in cityCountries), from the first flight or
+// hotel night there to the last flight out or hotel checkout. It is zero
+// when the session has no dated bookings in the country.
+func sessionTripDays(sessionID, country string) int {
+	in := func(city string) bool {
+		return cityCountries[strings.ToLower(strings.TrimSpace(city))] == country
+	}
+	var first, last time.Time
+	for _, b := range bookings.forSession(sessionID) {
+		if b.Cancelled {
+			continue
+		}
+		switch b.Kind {
+		case kindFlight:
+			if !in(b.Origin) && !in(b.Destination) {
+				continue
+			}
+		case kindHotel:
+			if !in(b.Location) {
+				continue
+			}
+		default:
+			continue
+		}
+		start, err := time.Parse(dateLayout, strings.TrimSpace(b.Date))
+		if err != nil {
+			continue
+		}
+		end := start
+		if b.Kind == kindHotel {
+			end = start.AddDate(0, 0, 1)
+		}
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if end.After(last) {
+			last = end
+		}
+	}
+	if first.IsZero() {
+		return 0
+	}
+	return int(last.Sub(first).Hours()/24) + 1
+}
+
+type getVisaFreeDurationArg struct {
+	Nationality string `json:"nationality" jsonschema:"the country issuing the traveler's passport"`
+	Destination string `json:"destination" jsonschema:"the destination country"`
+}
+type getVisaFreeDurationResult struct {
+	Status       string `json:"status"`
+	MaxStayDays  int    `json:"max_stay_days"`
+	TripDays     int    `json:"trip_days,omitempty"`
+	Warning      string `json:"warning,omitempty"`
+	Report       string `json:"report,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// getVisaFreeDuration reports how long a traveler may stay without a visa
+// and warns when the session's stay in the destination is longer.
+func getVisaFreeDuration(c tool.Context, arg getVisaFreeDurationArg) getVisaFreeDurationResult {
+	nationality := strings.ToLower(strings.TrimSpace(arg.Nationality))
+	destination := strings.ToLower(strings.TrimSpace(arg.Destination))
+	days, ok := visaFreeDays[nationality][destination]
+	if !ok {
+		return getVisaFreeDurationResult{
+			Status:       "error",
+			ErrorMessage: fmt.Sprintf("No visa information is available for %s citizens travelling to %s.", arg.Nationality, arg.Destination),
+		}
+	}
+	result := getVisaFreeDurationResult{
+		Status:      "success",
+		MaxStayDays: days,
+		TripDays:    sessionTripDays(c.SessionID(), destination),
+	}
+	if days == 0 {
+		result.Report = fmt.Sprintf("%s citizens need a visa for any stay in %s.", arg.Nationality, arg.Destination)
+	} else {
+		result.Report = fmt.Sprintf("%s citizens may stay in %s for up to %d days without a visa.", arg.Nationality, arg.Destination, days)
+	}
+	if days > 0 && result.TripDays > days {
+		result.Warning = fmt.Sprintf("The booked trip lasts %d days, longer than the %d day visa-free allowance; a visa is required.", result.TripDays, days)
+	}
+	return result
+}
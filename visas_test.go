@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestVisaFreeDurationWarnsOnLongTrip(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Dubai", Date: "2025-11-01"})
+	bookFlight(c, bookFlightArg{Origin: "Dubai", Destination: "London", Date: "2025-12-15"})
+
+	got := getVisaFreeDuration(c, getVisaFreeDurationArg{Nationality: "United Kingdom", Destination: "United Arab Emirates"})
+	if got.Status != "success" || got.MaxStayDays != 40 || got.TripDays != 45 {
+		t.Fatalf("got %+v, want a 45 day trip against a 40 day allowance", got)
+	}
+	if got.Warning == "" {
+		t.Error("no warning for a trip longer than the allowance")
+	}
+}
+
+func TestVisaFreeDurationShortTrip(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Dubai", Date: "2025-11-01"})
+	bookHotel(c, bookHotelArg{Location: "Dubai", Date: "2025-11-09"})
+
+	got := getVisaFreeDuration(c, getVisaFreeDurationArg{Nationality: "United Kingdom", Destination: "United Arab Emirates"})
+	if got.TripDays != 10 || got.Warning != "" {
+		t.Errorf("got %+v, want a 10 day trip without a warning", got)
+	}
+}
+
+func TestVisaFreeDurationCountsOnlyDestinationStay(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Dubai", Date: "2025-11-01"})
+	bookFlight(c, bookFlightArg{Origin: "Dubai", Destination: "Tokyo", Date: "2025-11-10"})
+	bookFlight(c, bookFlightArg{Origin: "Tokyo", Destination: "London", Date: "2025-12-20"})
+
+	got := getVisaFreeDuration(c, getVisaFreeDurationArg{Nationality: "United Kingdom", Destination: "United Arab Emirates"})
+	if got.TripDays != 10 || got.Warning != "" {
+		t.Errorf("got %+v, want only the 10 days in the UAE counted", got)
+	}
+}
+
+func TestVisaFreeDurationVisaAlwaysNeeded(t *testing.T) {
+	c := newTestContext(t)
+	bookFlight(c, bookFlightArg{Origin: "London", Destination: "Sydney", Date: "2025-11-01"})
+
+	got := getVisaFreeDuration(c, getVisaFreeDurationArg{Nationality: "United Kingdom", Destination: "Australia"})
+	if got.MaxStayDays != 0 || got.Warning != "" {
+		t.Errorf("got %+v, want no allowance warning when a visa is always needed", got)
+	}
+}